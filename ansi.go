@@ -0,0 +1,112 @@
+// Copyright 2026 Vedran Vuk. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package strutils
+
+import "strings"
+
+// ansiEscape is the byte that starts an ANSI escape sequence.
+const ansiEscape = 0x1b
+
+// ansiReset is the SGR sequence that resets all text attributes.
+const ansiReset = "\x1b[0m"
+
+// StripANSI returns s with ANSI escape sequences removed.
+//
+// Recognized are CSI sequences such as SGR colour codes, OSC sequences such
+// as hyperlinks, terminated by BEL or ST, and two-byte escapes.
+func StripANSI(s string) string {
+	if strings.IndexByte(s, ansiEscape) < 0 {
+		return s
+	}
+	var buf = make([]byte, 0, len(s))
+	for i := 0; i < len(s); {
+		if n := ansiLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		buf = append(buf, s[i])
+		i++
+	}
+	return UnsafeString(buf)
+}
+
+// ansiLen returns the length of the ANSI escape sequence at the start of s or
+// 0 if s does not start with one. An unterminated sequence extends to the
+// end of s.
+func ansiLen(s string) int {
+	if len(s) < 2 || s[0] != ansiEscape {
+		return 0
+	}
+	switch s[1] {
+	case '[':
+		// CSI: parameter and intermediate bytes followed by a final byte.
+		for i := 2; i < len(s); i++ {
+			if c := s[i]; c >= 0x40 && c <= 0x7e {
+				return i + 1
+			} else if c < 0x20 || c > 0x3f {
+				return i
+			}
+		}
+		return len(s)
+	case ']', 'P', '_', '^', 'X':
+		// OSC and other string sequences: terminated by BEL or ST.
+		for i := 2; i < len(s); i++ {
+			switch {
+			case s[i] == 0x07:
+				return i + 1
+			case s[i] == ansiEscape && i+1 < len(s) && s[i+1] == '\\':
+				return i + 2
+			}
+		}
+		return len(s)
+	}
+	// Intermediate bytes followed by a final byte.
+	for i := 1; i < len(s); i++ {
+		if c := s[i]; c >= 0x30 && c <= 0x7e {
+			return i + 1
+		} else if c < 0x20 || c > 0x2f {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// isSGR returns true if ANSI escape sequence seq is a Select Graphic
+// Rendition sequence.
+func isSGR(seq string) bool {
+	return len(seq) >= 3 && seq[1] == '[' && seq[len(seq)-1] == 'm'
+}
+
+// sgrStyle is the active text style as a sequence of SGR sequences that set
+// it since the last reset.
+type sgrStyle string
+
+// update returns the style after applying SGR sequence seq.
+func (self sgrStyle) update(seq string) sgrStyle {
+	switch params := seq[2 : len(seq)-1]; {
+	case params == "" || params == "0":
+		return ""
+	case strings.HasPrefix(params, "0;"):
+		return sgrStyle(seq)
+	}
+	return self + sgrStyle(seq)
+}
+
+// scan returns the style after applying all SGR sequences in s.
+func (self sgrStyle) scan(s string) sgrStyle {
+	for {
+		var i = strings.IndexByte(s, ansiEscape)
+		if i < 0 {
+			return self
+		}
+		var n = ansiLen(s[i:])
+		if n == 0 {
+			n = 1
+		} else if seq := s[i : i+n]; isSGR(seq) {
+			self = self.update(seq)
+		}
+		s = s[i+n:]
+	}
+}
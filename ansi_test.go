@@ -0,0 +1,30 @@
+package strutils
+
+import "testing"
+
+func TestStripANSI(t *testing.T) {
+	type stripSample struct {
+		in, out string
+	}
+	samples := []stripSample{
+		{"", ""},
+		{"plain", "plain"},
+		{"\x1b[31mred\x1b[0m", "red"},
+		{"\x1b[38;2;1;2;3mrgb\x1b[m!", "rgb!"},
+		{"\x1b]8;;http://example.com\x1b\\link\x1b]8;;\x1b\\", "link"},
+		{"\x1b]0;title\x07text", "text"},
+		{"\x1b(Bx\x1bcy", "xy"},
+		{"\x1b[31", ""},
+		{"a\x1b", "a\x1b"},
+	}
+
+	for _, sample := range samples {
+		if out := StripANSI(sample.in); out != sample.out {
+			t.Errorf("got %q from %q, expected %q", out, sample.in, sample.out)
+		}
+	}
+
+	if w := DisplayWidth("\x1b[1;31m日本\x1b[0m ok"); w != 7 {
+		t.Errorf("got width %d, expected 7", w)
+	}
+}
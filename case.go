@@ -8,26 +8,21 @@
 
 package strutils
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+)
 
 // Camelcase the given string.
 func CamelCase(s string) string {
-	var b = camelcase(s)
-	// the first byte must always be lowercase
-	if len(b) != 0 {
-		b[0] = ToLower(b[0])
-	}
-	return UnsafeString(b)
+	return UnsafeString(AppendCamelCase(make([]byte, 0, len(s)), s))
 }
 
 // Pascalcase the given string.
 func PascalCase(s string) string {
-	var b = camelcase(s)
-	// the first byte must always be uppercase
-	if len(b) != 0 {
-		b[0] = ToUpper(b[0])
-	}
-	return UnsafeString(b)
+	return UnsafeString(AppendPascalCase(make([]byte, 0, len(s)), s))
 }
 
 // Snakecase the given string.
@@ -36,64 +31,39 @@ func SnakeCase(s string) string { return separatorCase(s, underscoreByte) }
 // Kebabcase the given string.
 func KebabCase(s string) string { return separatorCase(s, dashByte) }
 
-func camelcase(s string) []byte {
-	b := make([]byte, 0, 64)
-	l := len(s)
-	i := 0
-
-	for i < l {
+// AppendCamelCase appends camelcased s to dst and returns the extended
+// buffer. It does not allocate if dst has enough capacity.
+func AppendCamelCase(dst []byte, s string) []byte { return appendCamelCase(dst, s, false) }
 
-		// skip leading bytes that aren't letters or digits
-		for i < l && !IsAlphanumeric(s[i]) {
-			i++
-		}
+// AppendPascalCase appends pascalcased s to dst and returns the extended
+// buffer. It does not allocate if dst has enough capacity.
+func AppendPascalCase(dst []byte, s string) []byte { return appendCamelCase(dst, s, true) }
 
-		// set the first byte to uppercase if it needs to
-		if i < l {
-			c := s[i]
-
-			// simply append contiguous digits
-			if IsDigit(c) {
-				for i < l {
-					if c = s[i]; !IsDigit(c) {
-						break
-					}
-					b = append(b, c)
-					i++
-				}
-				continue
-			}
+// AppendSnakeCase appends snakecased s to dst and returns the extended
+// buffer. It does not allocate if dst has enough capacity.
+func AppendSnakeCase(dst []byte, s string) []byte {
+	return appendSeparatorCase(dst, s, underscoreByte)
+}
 
-			// the sequence starts with and uppercase letter, we append
-			// all following uppercase letters as equivalent lowercases
-			if IsUpper(c) {
-				b = append(b, c)
-				i++
-
-				for i < l {
-					if c = s[i]; !IsUpper(c) {
-						break
-					}
-					b = append(b, ToLower(c))
-					i++
-				}
-
-			} else {
-				b = append(b, ToUpper(c))
-				i++
-			}
+// AppendKebabCase appends kebabcased s to dst and returns the extended
+// buffer. It does not allocate if dst has enough capacity.
+func AppendKebabCase(dst []byte, s string) []byte {
+	return appendSeparatorCase(dst, s, dashByte)
+}
 
-			// append all trailing lowercase letters
-			for i < l {
-				if c = s[i]; !IsLower(c) {
-					break
-				}
-				b = append(b, c)
-				i++
-			}
-		}
+// appendCamelCase appends s to dst as a sequence of words, each starting
+// with an uppercase letter followed by lowercase letters and digits.
+// If upper is false the first byte is lowercased.
+func appendCamelCase(dst []byte, s string, upper bool) []byte {
+	var first = len(dst)
+	for start, end := camelWords.next(s, 0); start < end; start, end = camelWords.next(s, end) {
+		dst = appendTitle(dst, s[start:end])
 	}
-	return b
+	// the first byte must always be lowercase
+	if !upper && len(dst) > first {
+		dst[first] = ToLower(dst[first])
+	}
+	return dst
 }
 
 const (
@@ -103,73 +73,95 @@ const (
 
 // separatorCase the given string.
 func separatorCase(s string, separator byte) string {
-	idx := 0
-	hasLower := false
-	hasSeparator := false
-	lowercaseSinceSeparator := false
+	if isSeparatorCase(s, separator) {
+		return s // no changes needed, can just borrow the string
+	}
+	// if we get here then we must need to manipulate the string
+	return UnsafeString(appendSeparatorWords(make([]byte, 0, len(s)+len(s)/2), s, separator))
+}
+
+// appendSeparatorCase appends separator cased s to dst.
+func appendSeparatorCase(dst []byte, s string, separator byte) []byte {
+	if isSeparatorCase(s, separator) {
+		return append(dst, s...)
+	}
+	return appendSeparatorWords(dst, s, separator)
+}
+
+// appendSeparatorWords appends lowercased words of s to dst separated by
+// separator.
+func appendSeparatorWords(dst []byte, s string, separator byte) []byte {
+	var first = true
+	for start, end := separatorWords.next(s, 0); start < end; start, end = separatorWords.next(s, end) {
+		if !first {
+			dst = append(dst, separator)
+		}
+		dst = appendLower(dst, s[start:end])
+		first = false
+	}
+	return dst
+}
 
+// isSeparatorCase returns true if s is already separator cased.
+func isSeparatorCase(s string, separator byte) bool {
 	// loop through all good characters:
 	// - lowercase
 	// - digit
-	// - underscore (as long as the next character is lowercase or digit)
-	for ; idx < len(s); idx++ {
-		if IsLower(s[idx]) {
-			hasLower = true
-			if hasSeparator {
-				lowercaseSinceSeparator = true
-			}
-			continue
-		} else if IsDigit(s[idx]) {
+	// - separator (as long as the next character is lowercase or digit)
+	for idx := 0; idx < len(s); idx++ {
+		if IsLower(s[idx]) || IsDigit(s[idx]) {
 			continue
 		} else if s[idx] == separator && idx > 0 && idx < len(s)-1 && (IsLower(s[idx+1]) || IsDigit(s[idx+1])) {
-			hasSeparator = true
-			lowercaseSinceSeparator = false
 			continue
 		}
-		break
+		return false
 	}
+	return true
+}
 
-	if idx == len(s) {
-		return s // no changes needed, can just borrow the string
-	}
-
-	// if we get here then we must need to manipulate the string
-	b := make([]byte, 0, 64)
-	b = append(b, s[:idx]...)
-
-	if IsUpper(s[idx]) && (!hasLower || hasSeparator && !lowercaseSinceSeparator) {
-		for idx < len(s) && (IsUpper(s[idx]) || IsDigit(s[idx])) {
-			b = append(b, asciiLowercaseArray[s[idx]])
-			idx++
+// isCamelCase returns true if s is already camel cased. If upper is true the
+// first letter must be uppercase as in PascalCase, lowercase otherwise.
+func isCamelCase(s string, upper bool) bool {
+	var prev = 0
+	for start, end := camelWords.next(s, 0); start < end; start, end = camelWords.next(s, end) {
+		if start != prev {
+			return false // separators are removed by camelcase
 		}
-
-		for idx < len(s) && (IsLower(s[idx]) || IsDigit(s[idx])) {
-			b = append(b, s[idx])
-			idx++
+		var c = s[start]
+		if start == 0 && !upper {
+			c = ToLower(c)
+		} else {
+			c = ToUpper(c)
 		}
-	}
-
-	for idx < len(s) {
-		if !IsAlphanumeric(s[idx]) {
-			idx++
-			continue
+		if c != s[start] {
+			return false
 		}
-
-		if len(b) > 0 {
-			b = append(b, separator)
+		for i := start + 1; i < end; i++ {
+			if IsUpper(s[i]) {
+				return false
+			}
 		}
+		prev = end
+	}
+	return prev == len(s)
+}
 
-		for idx < len(s) && (IsUpper(s[idx]) || IsDigit(s[idx])) {
-			b = append(b, asciiLowercaseArray[s[idx]])
-			idx++
-		}
+// appendLower appends lowercased word to b.
+func appendLower(b []byte, word string) []byte {
+	for i := 0; i < len(word); i++ {
+		b = append(b, asciiLowercaseArray[word[i]])
+	}
+	return b
+}
 
-		for idx < len(s) && (IsLower(s[idx]) || IsDigit(s[idx])) {
-			b = append(b, s[idx])
-			idx++
-		}
+// appendTitle appends word to b with the first byte uppercased and the rest
+// lowercased.
+func appendTitle(b []byte, word string) []byte {
+	if len(word) == 0 {
+		return b
 	}
-	return UnsafeString(b) // return manipulated string
+	b = append(b, ToUpper(word[0]))
+	return appendLower(b, word[1:])
 }
 
 var asciiLowercaseArray = [256]byte{
@@ -253,22 +245,108 @@ func (self CaseMapping) MarshalText() (text []byte, err error) {
 }
 
 // UnmarshalText implementes encoding.TextUnmarshaler on CaseMapping.
-func (self *CaseMapping) UnmarshalText(text []byte) error {
-	switch string(text) {
-	case "NoMapping":
-		*self = NoMapping
-	case "PascalMapping":
-		*self = PascalMapping
-	case "SnakeMapping":
-		*self = SnakeMapping
-	case "CamelMapping":
-		*self = CamelMapping
-	case "KebabMapping":
-		*self = KebabMapping
-	default:
-		*self = InvalidMapping
+//
+// See [ParseCaseMapping] for accepted names.
+func (self *CaseMapping) UnmarshalText(text []byte) (err error) {
+	*self, err = ParseCaseMapping(string(text))
+	return
+}
+
+// MarshalJSON implements json.Marshaler on CaseMapping.
+func (self CaseMapping) MarshalJSON() ([]byte, error) {
+	return json.Marshal(self.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler on CaseMapping.
+//
+// Data must be a JSON string containing a name accepted by
+// [ParseCaseMapping]. A JSON null is a no-op.
+func (self *CaseMapping) UnmarshalJSON(data []byte) (err error) {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err = json.Unmarshal(data, &s); err != nil {
+		return
 	}
-	return errors.New("unknown mapping: " + string(text))
+	*self, err = ParseCaseMapping(s)
+	return
+}
+
+// ParseCaseMapping returns a CaseMapping by name.
+//
+// Name is matched case-insensitively and with any spaces, underscores and
+// dashes removed. Accepted names are the mapping names as returned by
+// [CaseMapping.String] and their aliases:
+//
+//	NoMapping:     "none", "no"
+//	PascalMapping: "pascal", "PascalCase", "pascal_case", "pascal-case"
+//	SnakeMapping:  "snake", "snake_case", "snake-case"
+//	CamelMapping:  "camel", "camelCase", "camel_case", "camel-case"
+//	KebabMapping:  "kebab", "kebab-case", "kebab_case"
+//
+// If name is not recognized result is [InvalidMapping] and an error.
+func ParseCaseMapping(name string) (CaseMapping, error) {
+	var b = make([]byte, 0, len(name))
+	for i := 0; i < len(name); i++ {
+		switch c := name[i]; c {
+		case ' ', underscoreByte, dashByte:
+		default:
+			b = append(b, ToLower(c))
+		}
+	}
+	switch string(b) {
+	case "nomapping", "none", "no":
+		return NoMapping, nil
+	case "pascalmapping", "pascal", "pascalcase":
+		return PascalMapping, nil
+	case "snakemapping", "snake", "snakecase":
+		return SnakeMapping, nil
+	case "camelmapping", "camel", "camelcase":
+		return CamelMapping, nil
+	case "kebabmapping", "kebab", "kebabcase":
+		return KebabMapping, nil
+	}
+	return InvalidMapping, errors.New("unknown mapping: " + name)
+}
+
+// Append appends s case mapped depending on self value to dst and returns
+// the extended buffer. If mapping value is unknown s is appended unmodified.
+// It does not allocate if dst has enough capacity.
+func (self CaseMapping) Append(dst []byte, s string) []byte {
+	switch self {
+	case PascalMapping:
+		return AppendPascalCase(dst, s)
+	case SnakeMapping:
+		return AppendSnakeCase(dst, s)
+	case CamelMapping:
+		return AppendCamelCase(dst, s)
+	case KebabMapping:
+		return AppendKebabCase(dst, s)
+	}
+	return append(dst, s...)
+}
+
+// MapTo writes s case mapped depending on self value to w and returns the
+// number of bytes written and any error that occurred. If mapping value is
+// unknown s is written unmodified.
+//
+// The mapped string is built in a pooled buffer, so MapTo does not allocate
+// once the pool is warm.
+func (self CaseMapping) MapTo(w io.Writer, s string) (int, error) {
+	var buf = mapToBuffers.Get().(*[]byte)
+	*buf = self.Append((*buf)[:0], s)
+	var n, err = w.Write(*buf)
+	mapToBuffers.Put(buf)
+	return n, err
+}
+
+// mapToBuffers are buffers of [CaseMapping.MapTo].
+var mapToBuffers = sync.Pool{
+	New: func() any {
+		var buf = make([]byte, 0, 64)
+		return &buf
+	},
 }
 
 // Map case maps s depending on self value.
@@ -286,3 +364,45 @@ func (self CaseMapping) Map(s string) string {
 	}
 	return s
 }
+
+// IsCase returns true if s is already cased as specified by m, i.e. mapping
+// s with m would not modify it.
+//
+// Returns true for any s if m is [NoMapping] and false for an unknown m.
+func IsCase(s string, m CaseMapping) bool {
+	switch m {
+	case NoMapping:
+		return true
+	case PascalMapping:
+		return isCamelCase(s, true)
+	case SnakeMapping:
+		return isSeparatorCase(s, underscoreByte)
+	case CamelMapping:
+		return isCamelCase(s, false)
+	case KebabMapping:
+		return isSeparatorCase(s, dashByte)
+	}
+	return false
+}
+
+// DetectCase returns the [CaseMapping] s is cased with.
+//
+// A string may conform to multiple mappings, for instance a single lowercase
+// word is a valid snake_case, kebab-case and camelCase string. In that case
+// the first matching mapping in order SnakeMapping, KebabMapping,
+// CamelMapping, PascalMapping is returned. Use [IsCase] to test s against a
+// specific mapping.
+//
+// If s contains no words [InvalidMapping] is returned. If s does not conform
+// to any mapping, i.e. it mixes styles, [NoMapping] is returned.
+func DetectCase(s string) CaseMapping {
+	if start, end := separatorWords.next(s, 0); start == end {
+		return InvalidMapping
+	}
+	for _, m := range []CaseMapping{SnakeMapping, KebabMapping, CamelMapping, PascalMapping} {
+		if IsCase(s, m) {
+			return m
+		}
+	}
+	return NoMapping
+}
@@ -1,6 +1,12 @@
 package strutils
 
-import "testing"
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
 
 var ops int = 1e6
 
@@ -163,3 +169,278 @@ func BenchmarkDigitUpper2(b *testing.B) {
 		separatorCase(s, underscoreByte)
 	}
 }
+
+func TestDetectCase(t *testing.T) {
+	type detectSample struct {
+		str string
+		out CaseMapping
+	}
+	samples := []detectSample{
+		{"", InvalidMapping},
+		{"  _-$ ", InvalidMapping},
+		{"sample", SnakeMapping},
+		{"sample_text", SnakeMapping},
+		{"sample_2_text", SnakeMapping},
+		{"sample-text", KebabMapping},
+		{"sampleText", CamelMapping},
+		{"sample2Text", CamelMapping},
+		{"SampleText", PascalMapping},
+		{"Sample", PascalMapping},
+		{"sample_text-here", NoMapping},
+		{"sample_Text", NoMapping},
+		{"Sample_text", NoMapping},
+		{"sample text", NoMapping},
+		{"SAMPLE", NoMapping},
+		{"sampleTEXT", NoMapping},
+		{"_sample", NoMapping},
+	}
+
+	for _, sample := range samples {
+		if out := DetectCase(sample.str); out != sample.out {
+			t.Errorf("got %v from %q, expected %v", out, sample.str, sample.out)
+		}
+	}
+}
+
+func TestIsCase(t *testing.T) {
+	var inputs = []string{
+		"", "sample", "sample_text", "sample-text", "sampleText", "SampleText",
+		"SAMPLE 2 TEXT", "___$$Base64Encode", "lk0B@bFmjrLQ_Z6YL", "5abc",
+		"2FA Enabled", "edf_6N", "CStringRef",
+	}
+	var mappings = []CaseMapping{PascalMapping, SnakeMapping, CamelMapping, KebabMapping}
+
+	for _, in := range inputs {
+		for _, m := range mappings {
+			if out, expected := IsCase(in, m), m.Map(in) == in; out != expected {
+				t.Errorf("got %t from %q for %v, expected %t", out, in, m, expected)
+			}
+			if m != SnakeMapping && m != KebabMapping {
+				continue
+			}
+			if out := m.Map(in); !IsCase(out, m) {
+				t.Errorf("mapped %q to %q is not %v", in, out, m)
+			}
+		}
+	}
+	if !IsCase("any thing", NoMapping) {
+		t.Error("IsCase failed for NoMapping")
+	}
+	if IsCase("sample", InvalidMapping) {
+		t.Error("IsCase failed for InvalidMapping")
+	}
+}
+
+func TestParseCaseMapping(t *testing.T) {
+	type parseSample struct {
+		str string
+		out CaseMapping
+	}
+	samples := []parseSample{
+		{"NoMapping", NoMapping},
+		{"none", NoMapping},
+		{"PascalMapping", PascalMapping},
+		{"PascalCase", PascalMapping},
+		{"pascal", PascalMapping},
+		{"SnakeMapping", SnakeMapping},
+		{"snake", SnakeMapping},
+		{"snake_case", SnakeMapping},
+		{"SNAKE-CASE", SnakeMapping},
+		{"CamelMapping", CamelMapping},
+		{"camel", CamelMapping},
+		{"camelCase", CamelMapping},
+		{"KebabMapping", KebabMapping},
+		{"kebab-case", KebabMapping},
+		{"Kebab Case", KebabMapping},
+	}
+
+	for _, sample := range samples {
+		out, err := ParseCaseMapping(sample.str)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", sample.str, err)
+		}
+		if out != sample.out {
+			t.Errorf("got %v from %q, expected %v", out, sample.str, sample.out)
+		}
+	}
+
+	for _, name := range []string{"", "InvalidMapping", "snakes", "title"} {
+		if out, err := ParseCaseMapping(name); err == nil || out != InvalidMapping {
+			t.Errorf("expected error and InvalidMapping for %q, got %v, %v", name, out, err)
+		}
+	}
+}
+
+func TestCaseMappingText(t *testing.T) {
+	for _, m := range []CaseMapping{NoMapping, PascalMapping, SnakeMapping, CamelMapping, KebabMapping} {
+		text, err := m.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out CaseMapping
+		if err = out.UnmarshalText(text); err != nil {
+			t.Errorf("unexpected error for %q: %v", text, err)
+		}
+		if out != m {
+			t.Errorf("got %v from %q, expected %v", out, text, m)
+		}
+	}
+}
+
+func TestCaseMappingJSON(t *testing.T) {
+	type config struct {
+		Mapping CaseMapping `json:"mapping"`
+	}
+
+	var out config
+	if err := json.Unmarshal([]byte(`{"mapping":"kebab-case"}`), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Mapping != KebabMapping {
+		t.Errorf("got %v, expected %v", out.Mapping, KebabMapping)
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"mapping":"KebabMapping"}` {
+		t.Errorf("got %s", data)
+	}
+
+	if err = json.Unmarshal([]byte(`{"mapping":"upside-down"}`), &out); err == nil {
+		t.Error("expected error for unknown mapping")
+	}
+	if err = json.Unmarshal([]byte(`{"mapping":42}`), &out); err == nil {
+		t.Error("expected error for non-string mapping")
+	}
+}
+
+func TestAppendCase(t *testing.T) {
+	type appendSample struct {
+		mapping CaseMapping
+		str     string
+		out     string
+	}
+	samples := []appendSample{
+		{CamelMapping, "sample text", "prefix:sampleText"},
+		{PascalMapping, "sample text", "prefix:SampleText"},
+		{SnakeMapping, "sampleText", "prefix:sample_text"},
+		{SnakeMapping, "sample_text", "prefix:sample_text"},
+		{KebabMapping, "SAMPLE 2 TEXT", "prefix:sample-2-text"},
+		{NoMapping, "sample text", "prefix:sample text"},
+		{CamelMapping, "$#$", "prefix:"},
+	}
+
+	for _, sample := range samples {
+		if out := string(sample.mapping.Append([]byte("prefix:"), sample.str)); out != sample.out {
+			t.Errorf("got %q from %q, expected %q", out, sample.str, sample.out)
+		}
+		if out := sample.mapping.Map(sample.str); "prefix:"+out != sample.out {
+			t.Errorf("got %q from %q, expected %q", out, sample.str, sample.out)
+		}
+	}
+}
+
+func TestAppendCaseAllocs(t *testing.T) {
+	var (
+		s   = "FOO:BAR$BAZ__Sample    Text___"
+		buf = make([]byte, 0, 128)
+	)
+	for _, m := range []CaseMapping{PascalMapping, SnakeMapping, CamelMapping, KebabMapping} {
+		if n := testing.AllocsPerRun(100, func() { m.Append(buf[:0], s) }); n != 0 {
+			t.Errorf("%v: got %v allocs, expected 0", m, n)
+		}
+	}
+}
+
+func TestCaseMappingMapTo(t *testing.T) {
+	var samples = []struct {
+		mapping CaseMapping
+		str     string
+		out     string
+	}{
+		{CamelMapping, "sample text", "sampleText"},
+		{PascalMapping, "sample text", "SampleText"},
+		{SnakeMapping, "sampleText", "sample_text"},
+		{KebabMapping, "SAMPLE 2 TEXT", "sample-2-text"},
+		{NoMapping, "sample text", "sample text"},
+		{CamelMapping, "$#$", ""},
+		{SnakeMapping, strings.Repeat("LongWord", 20), strings.TrimSuffix(strings.Repeat("long_word_", 20), "_")},
+	}
+	for _, sample := range samples {
+		var b strings.Builder
+		b.WriteString("prefix:")
+		n, err := sample.mapping.MapTo(&b, sample.str)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out := b.String(); out != "prefix:"+sample.out || n != len(sample.out) {
+			t.Errorf("got %q, %d from %q, expected %q, %d", out, n, sample.str, "prefix:"+sample.out, len(sample.out))
+		}
+	}
+
+	var errWrite = errors.New("write failed")
+	if _, err := SnakeMapping.MapTo(failWriter{errWrite}, "sampleText"); err != errWrite {
+		t.Errorf("got error %v, expected %v", err, errWrite)
+	}
+
+	var s = "FOO:BAR$BAZ__Sample    Text___"
+	SnakeMapping.MapTo(io.Discard, s)
+	if n := testing.AllocsPerRun(100, func() { SnakeMapping.MapTo(io.Discard, s) }); n != 0 {
+		t.Errorf("got %v allocs, expected 0", n)
+	}
+}
+
+// failWriter is an io.Writer that fails with err.
+type failWriter struct{ err error }
+
+func (self failWriter) Write(p []byte) (int, error) { return 0, self.err }
+
+func BenchmarkAppendCamelCase(b *testing.B) {
+	var (
+		s   = "some sample text here_noething:too$amazing"
+		buf = make([]byte, 0, 64)
+	)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		buf = AppendCamelCase(buf[:0], s)
+	}
+}
+
+func BenchmarkAppendPascalCase(b *testing.B) {
+	var (
+		s   = "some sample text here_noething:too$amazing"
+		buf = make([]byte, 0, 64)
+	)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		buf = AppendPascalCase(buf[:0], s)
+	}
+}
+
+func BenchmarkAppendSnakeCase(b *testing.B) {
+	var (
+		s   = "inviteYourCustomersAddInvites"
+		buf = make([]byte, 0, 64)
+	)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		buf = AppendSnakeCase(buf[:0], s)
+	}
+}
+
+func BenchmarkAppendKebabCase(b *testing.B) {
+	var (
+		s   = "inviteYourCustomersAddInvites"
+		buf = make([]byte, 0, 64)
+	)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		buf = AppendKebabCase(buf[:0], s)
+	}
+}
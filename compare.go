@@ -0,0 +1,302 @@
+// Copyright 2026 Vedran Vuk. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package strutils
+
+import (
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NaturalOrder compares strings in natural order where runs of ASCII digits
+// are compared by their numeric value, so that "file2" sorts before
+// "file10". Runs of digits of any length are supported.
+//
+// If two numbers are equal by value the one with fewer leading zeros sorts
+// first, unless strings differ elsewhere.
+type NaturalOrder struct {
+	// Fold, if true, compares text case-insensitively as [CompareFold].
+	Fold bool
+
+	// IgnoreLeadingZeros, if true, makes numbers equal by value equal
+	// regardless of leading zeros, so that "a01" equals "a1".
+	IgnoreLeadingZeros bool
+}
+
+// CompareNatural returns 1 if a > b, -1 if a < b or 0 if a == b in natural
+// order. See [NaturalOrder].
+func CompareNatural(a, b string) int { return NaturalOrder{}.Compare(a, b) }
+
+// CompareNaturalFold is the case-insensitive version of CompareNatural.
+func CompareNaturalFold(a, b string) int { return NaturalOrder{Fold: true}.Compare(a, b) }
+
+// SortNatural sorts s in natural order. See [NaturalOrder].
+func SortNatural(s []string) { slices.SortFunc(s, CompareNatural) }
+
+// SortNaturalFold sorts s in case-insensitive natural order.
+func SortNaturalFold(s []string) { slices.SortFunc(s, CompareNaturalFold) }
+
+// Compare returns 1 if a > b, -1 if a < b or 0 if a == b.
+// It does not allocate.
+func (self NaturalOrder) Compare(a, b string) int {
+	var zeros = 0 // leading zeros tie-breaker
+	for len(a) > 0 && len(b) > 0 {
+		if IsDigit(a[0]) && IsDigit(b[0]) {
+			var na, nb = digitRun(a), digitRun(b)
+			var c, z = compareNumbers(a[:na], b[:nb])
+			if c != 0 {
+				return c
+			}
+			if zeros == 0 && !self.IgnoreLeadingZeros {
+				zeros = z
+			}
+			a, b = a[na:], b[nb:]
+			continue
+		}
+		var (
+			ra, sa = utf8.DecodeRuneInString(a)
+			rb, sb = utf8.DecodeRuneInString(b)
+		)
+		if c := compareRunes(ra, rb, self.Fold); c != 0 {
+			return c
+		}
+		a, b = a[sa:], b[sb:]
+	}
+	if len(a) != len(b) {
+		return sign(len(a) - len(b))
+	}
+	return zeros
+}
+
+// Less returns true if a sorts before b.
+func (self NaturalOrder) Less(a, b string) bool { return self.Compare(a, b) < 0 }
+
+// Sort sorts s in order defined by self.
+func (self NaturalOrder) Sort(s []string) { slices.SortFunc(s, self.Compare) }
+
+// digitRun returns the length of ASCII digits prefix of s.
+func digitRun(s string) (n int) {
+	for n < len(s) && IsDigit(s[n]) {
+		n++
+	}
+	return
+}
+
+// compareNumbers compares decimal numbers a and b by value and returns the
+// result and, if they are equal, a leading zero tie-breaker that orders the
+// number with fewer leading zeros first.
+func compareNumbers(a, b string) (c, zeros int) {
+	var za, zb = 0, 0
+	for za < len(a)-1 && a[za] == '0' {
+		za++
+	}
+	for zb < len(b)-1 && b[zb] == '0' {
+		zb++
+	}
+	var da, db = a[za:], b[zb:]
+	switch {
+	case len(da) < len(db):
+		return -1, 0
+	case len(da) > len(db):
+		return 1, 0
+	}
+	if c = Compare(da, db); c != 0 {
+		return c, 0
+	}
+	return 0, sign(len(a) - len(b))
+}
+
+// sign returns the sign of i.
+func sign(i int) int {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	}
+	return 0
+}
+
+// compareRunes compares runes a and b, case-insensitively as
+// compareFoldRune if fold is true.
+func compareRunes(a, b rune, fold bool) int {
+	if fold {
+		return compareFoldRune(a, b)
+	}
+	if a == b {
+		return 0
+	}
+	if a < b {
+		return -1
+	}
+	return 1
+}
+
+// CollationStrength specifies which differences between strings are
+// significant to a [Collator].
+type CollationStrength int
+
+const (
+	// PrimaryStrength compares base letters only, ignoring accents and case,
+	// so that "resume", "résumé" and "RESUME" are equal.
+	PrimaryStrength CollationStrength = iota
+	// SecondaryStrength compares base letters and, if those are equal,
+	// accents, ignoring case.
+	SecondaryStrength
+	// TertiaryStrength compares base letters, then accents and then case,
+	// with lowercase sorting first.
+	TertiaryStrength
+)
+
+// Collator compares strings in a language-neutral, collation-style order.
+//
+// Strings are first compared by base letters where accented latin letters
+// are compared as their unaccented letters, ligatures and special letters as
+// their spelling, e.g. "ß" as "ss", and combining marks are ignored. If base
+// letters are equal and Strength requires so, accents and then case decide
+// the order.
+//
+// Precomposed and decomposed forms of an accented letter are equal by base
+// letters but differ by accents.
+type Collator struct {
+	// Strength specifies significant differences.
+	//
+	// Default: PrimaryStrength
+	Strength CollationStrength
+
+	// IgnorePunctuation, if true, ignores punctuation, symbols and white
+	// space at all strengths, so that "co-op" equals "coop".
+	IgnorePunctuation bool
+}
+
+// Compare returns 1 if a > b, -1 if a < b or 0 if a == b.
+// It does not allocate.
+func (self Collator) Compare(a, b string) int {
+	var ca, cb = collateCursor{s: a, skip: self.IgnorePunctuation}, collateCursor{s: b, skip: self.IgnorePunctuation}
+	for {
+		var ra, oka = ca.next()
+		var rb, okb = cb.next()
+		switch {
+		case !oka && !okb:
+			goto secondary
+		case !oka:
+			return -1
+		case !okb:
+			return 1
+		case ra < rb:
+			return -1
+		case ra > rb:
+			return 1
+		}
+	}
+secondary:
+	if self.Strength < SecondaryStrength {
+		return 0
+	}
+	if c := self.compareLevel(a, b, accentWeight); c != 0 || self.Strength < TertiaryStrength {
+		return c
+	}
+	return self.compareLevel(a, b, caseWeight)
+}
+
+// Less returns true if a sorts before b.
+func (self Collator) Less(a, b string) bool { return self.Compare(a, b) < 0 }
+
+// Sort sorts s in order defined by self.
+func (self Collator) Sort(s []string) { slices.SortFunc(s, self.Compare) }
+
+// compareLevel compares weights of runes of a and b not ignored by self.
+func (self Collator) compareLevel(a, b string, weight func(rune) rune) int {
+	for {
+		var ra, sa = self.nextRune(a)
+		var rb, sb = self.nextRune(b)
+		switch {
+		case sa == 0 && sb == 0:
+			return 0
+		case sa == 0:
+			return -1
+		case sb == 0:
+			return 1
+		}
+		if wa, wb := weight(ra), weight(rb); wa != wb {
+			if wa < wb {
+				return -1
+			}
+			return 1
+		}
+		a, b = a[sa:], b[sb:]
+	}
+}
+
+// nextRune returns the first rune of s not ignored by self and the length
+// of s up to and including it, or 0 and 0 if there are none.
+func (self Collator) nextRune(s string) (r rune, n int) {
+	for n < len(s) {
+		var size int
+		r, size = utf8.DecodeRuneInString(s[n:])
+		n += size
+		if !self.IgnorePunctuation || !isCollationIgnorable(r) {
+			return r, n
+		}
+	}
+	return 0, 0
+}
+
+// accentWeight returns the secondary weight of r: zero for unaccented runes,
+// the lowercase rune for accented latin letters and combining marks.
+func accentWeight(r rune) rune {
+	if unicode.Is(unicode.Mn, r) {
+		return r
+	}
+	if _, accented := latinBase[r]; accented {
+		return unicode.ToLower(r)
+	}
+	return 0
+}
+
+// caseWeight returns the tertiary weight of r: one for uppercase and
+// titlecase letters, zero otherwise.
+func caseWeight(r rune) rune {
+	if unicode.IsUpper(r) || unicode.IsTitle(r) {
+		return 1
+	}
+	return 0
+}
+
+// isCollationIgnorable returns true if r is punctuation, a symbol or space.
+func isCollationIgnorable(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r)
+}
+
+// collateCursor iterates over primary collation weights of a string.
+type collateCursor struct {
+	s    string // remaining input
+	base string // remaining expansion of the current rune
+	skip bool   // skip punctuation
+}
+
+// next returns the next primary weight and true or 0 and false at the end.
+func (self *collateCursor) next() (rune, bool) {
+	for {
+		if len(self.base) > 0 {
+			var r, size = utf8.DecodeRuneInString(self.base)
+			self.base = self.base[size:]
+			return unicode.ToLower(r), true
+		}
+		if len(self.s) == 0 {
+			return 0, false
+		}
+		var r, size = utf8.DecodeRuneInString(self.s)
+		self.s = self.s[size:]
+		if unicode.Is(unicode.Mn, r) || self.skip && isCollationIgnorable(r) {
+			continue
+		}
+		if base, exists := latinBase[r]; exists {
+			self.base = base
+			continue
+		}
+		return unicode.ToLower(r), true
+	}
+}
@@ -0,0 +1,133 @@
+package strutils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompareNatural(t *testing.T) {
+	type compareSample struct {
+		order NaturalOrder
+		a, b  string
+		out   int
+	}
+	samples := []compareSample{
+		{NaturalOrder{}, "", "", 0},
+		{NaturalOrder{}, "", "a", -1},
+		{NaturalOrder{}, "file2", "file10", -1},
+		{NaturalOrder{}, "file10", "file2", 1},
+		{NaturalOrder{}, "file10", "file10", 0},
+		{NaturalOrder{}, "a2b3", "a2b10", -1},
+		{NaturalOrder{}, "x99999999999999999999999", "x100000000000000000000000", -1},
+		{NaturalOrder{}, "a1", "a01", -1},
+		{NaturalOrder{}, "a01", "a1", 1},
+		{NaturalOrder{}, "a01b", "a1c", -1},
+		{NaturalOrder{}, "a0", "a00", -1},
+		{NaturalOrder{}, "a1", "a1b", -1},
+		{NaturalOrder{}, "a", "1", 1},
+		{NaturalOrder{}, "File2", "file10", -1},
+		{NaturalOrder{}, "file2", "File10", 1},
+		{NaturalOrder{IgnoreLeadingZeros: true}, "a01", "a1", 0},
+		{NaturalOrder{IgnoreLeadingZeros: true}, "a01", "a2", -1},
+		{NaturalOrder{Fold: true}, "File2", "file10", -1},
+		{NaturalOrder{Fold: true}, "FILE10", "file10", 0},
+		{NaturalOrder{Fold: true}, "ČAJ7", "čaj07", -1},
+		{NaturalOrder{Fold: true}, "a_", "A1", 1},
+		{NaturalOrder{Fold: true}, "ſ", "s", 0},
+		{NaturalOrder{Fold: true}, "s", "t", -1},
+		{NaturalOrder{Fold: true}, "ſ", "t", -1},
+		{NaturalOrder{Fold: true}, "ſ2", "S10", -1},
+	}
+
+	for _, sample := range samples {
+		if out := sample.order.Compare(sample.a, sample.b); out != sample.out {
+			t.Errorf("got %d comparing %q and %q %+v, expected %d", out, sample.a, sample.b, sample.order, sample.out)
+		}
+		if less := sample.order.Less(sample.a, sample.b); less != (sample.out < 0) {
+			t.Errorf("Less failed for %q and %q", sample.a, sample.b)
+		}
+	}
+
+	var names = []string{"img12.png", "img10.png", "IMG2.png", "img1.png", "img02.png"}
+	SortNaturalFold(names)
+	if expected := []string{"img1.png", "IMG2.png", "img02.png", "img10.png", "img12.png"}; !slices.Equal(names, expected) {
+		t.Errorf("got %q, expected %q", names, expected)
+	}
+	var folded = []string{"t", "ſ", "T", "s", "ẞ", "ß"}
+	SortNaturalFold(folded)
+	for i := range folded {
+		for j := i + 1; j < len(folded); j++ {
+			if CompareNaturalFold(folded[i], folded[j]) > 0 {
+				t.Errorf("SortNaturalFold ordering inconsistent: %q", folded)
+			}
+		}
+	}
+	SortNatural(names)
+	if expected := []string{"IMG2.png", "img1.png", "img02.png", "img10.png", "img12.png"}; !slices.Equal(names, expected) {
+		t.Errorf("got %q, expected %q", names, expected)
+	}
+}
+
+func TestCollator(t *testing.T) {
+	type collateSample struct {
+		collator Collator
+		a, b     string
+		out      int
+	}
+	var (
+		primary   = Collator{}
+		secondary = Collator{Strength: SecondaryStrength}
+		tertiary  = Collator{Strength: TertiaryStrength}
+	)
+	samples := []collateSample{
+		{primary, "", "", 0},
+		{primary, "resume", "Résumé", 0},
+		{primary, "RESUME", "résumé", 0},
+		{primary, "straße", "STRASSE", 0},
+		{primary, "é", "é", 0},
+		{primary, "cote", "côté", 0},
+		{primary, "čaj", "caj", 0},
+		{primary, "čaj", "dom", -1},
+		{primary, "Zebra", "apple", 1},
+		{primary, "co-op", "coop", -1},
+		{Collator{IgnorePunctuation: true}, "co-op", "coop", 0},
+		{Collator{IgnorePunctuation: true}, "re sume!", "résumé", 0},
+		{secondary, "resume", "résumé", -1},
+		{secondary, "RÉSUMÉ", "résumé", 0},
+		{secondary, "cote", "côte", -1},
+		{secondary, "côte", "coté", 1},
+		{Collator{Strength: SecondaryStrength, IgnorePunctuation: true}, "co-té", "coté", 0},
+		{tertiary, "resume", "Resume", -1},
+		{tertiary, "Resume", "résumé", -1},
+		{tertiary, "résumé", "résumé", 0},
+		{Collator{Strength: TertiaryStrength, IgnorePunctuation: true}, "a-B", "a b", 1},
+	}
+
+	for _, sample := range samples {
+		if out := sample.collator.Compare(sample.a, sample.b); out != sample.out {
+			t.Errorf("got %d comparing %q and %q %+v, expected %d", out, sample.a, sample.b, sample.collator, sample.out)
+		}
+		if out := sample.collator.Compare(sample.b, sample.a); out != -sample.out {
+			t.Errorf("got %d comparing %q and %q %+v, expected %d", out, sample.b, sample.a, sample.collator, -sample.out)
+		}
+	}
+
+	var words = []string{"zebra", "Éclair", "eclair", "apple", "Apple", "échelle"}
+	tertiary.Sort(words)
+	if expected := []string{"apple", "Apple", "échelle", "eclair", "Éclair", "zebra"}; !slices.Equal(words, expected) {
+		t.Errorf("got %q, expected %q", words, expected)
+	}
+}
+
+func TestCompareAllocs(t *testing.T) {
+	var (
+		natural  = NaturalOrder{Fold: true}
+		collator = Collator{Strength: TertiaryStrength, IgnorePunctuation: true}
+	)
+	if n := testing.AllocsPerRun(100, func() {
+		natural.Compare("Report 2024-01.PDF", "report 2024-1.pdf")
+		collator.Compare("Straße-Café", "strasse cafe")
+	}); n != 0 {
+		t.Errorf("got %v allocs, expected 0", n)
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 Vedran Vuk. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package strutils
+
+import "strings"
+
+// Alignment specifies horizontal alignment of text.
+type Alignment int
+
+const (
+	// AlignLeft aligns text to the left edge.
+	AlignLeft Alignment = iota
+	// AlignRight aligns text to the right edge.
+	AlignRight
+	// AlignCenter centers text, leaning left if it can not be centered
+	// exactly.
+	AlignCenter
+	// AlignJustify stretches lines to both edges by widening spaces between
+	// words, except the last line of a paragraph which is aligned left.
+	AlignJustify
+)
+
+// String implements fmt.Stringer on Alignment.
+func (self Alignment) String() string {
+	switch self {
+	case AlignLeft:
+		return "Left"
+	case AlignRight:
+		return "Right"
+	case AlignCenter:
+		return "Center"
+	case AlignJustify:
+		return "Justify"
+	}
+	return "Invalid"
+}
+
+// TextFormatter formats text into paragraphs of wrapped lines.
+//
+// Text is split into paragraphs at blank lines which are kept as they are.
+// Lines of a paragraph are joined and wrapped again with [WrapText] rules.
+//
+// A line that starts with a list marker, a "-", "*", "+" or "•" bullet or a
+// number followed by "." or ")", followed by a space, starts a list item
+// that extends to the next blank line or list item. Items are indented by
+// their indentation in text and wrapped lines of an item are aligned with
+// the text after the marker, so that nested lists keep their shape.
+type TextFormatter struct {
+	// Width is the maximum width of lines in columns, including prefix and
+	// indentation. If zero or less lines are not wrapped and Align has no
+	// effect.
+	Width int
+
+	// Indent is the number of spaces before the first line of a paragraph.
+	Indent int
+
+	// HangingIndent is the number of spaces before the lines of a paragraph
+	// other than the first.
+	HangingIndent int
+
+	// Prefix is written at the start of every line before indentation, for
+	// instance "// " or "> ". Trailing white space of Prefix is removed on
+	// blank lines.
+	Prefix string
+
+	// Align is the alignment of lines within the space after prefix and
+	// indentation.
+	Align Alignment
+
+	// Force, if true, splits words longer than the line width.
+	Force bool
+
+	// Hyphenate, if true, inserts a hyphen where Force splits a word between
+	// two letters.
+	Hyphenate bool
+}
+
+// Format returns text formatted into lines joined by newlines.
+func (self TextFormatter) Format(text string) string {
+	return strings.Join(self.Lines(text), "\n")
+}
+
+// Lines returns text formatted into lines.
+func (self TextFormatter) Lines(text string) (out []string) {
+	var (
+		w     = &textWrapper{WrapOptions: WrapOptions{Force: self.Force, Hyphenate: self.Hyphenate}}
+		para  []string // lines of the current paragraph
+		first string   // indentation and marker of the first line
+		rest  string   // indentation of other lines
+	)
+	var flush = func() {
+		if para != nil {
+			out = self.paragraph(out, w, strings.Join(para, " "), first, rest)
+			para = nil
+		}
+	}
+	for len(text) > 0 {
+		var raw, remaining, _ = strings.Cut(text, "\n")
+		text = remaining
+		raw = strings.TrimSuffix(raw, "\r")
+		var (
+			line                 = strings.TrimSpace(raw)
+			indent, marker, item = listItem(raw)
+		)
+		switch {
+		case line == "":
+			flush()
+			out = append(out, strings.TrimRight(self.Prefix, " \t"))
+		case item:
+			flush()
+			var lead = strings.Repeat(" ", self.Indent+indent)
+			first = lead + marker + " "
+			rest = lead + strings.Repeat(" ", DisplayWidth(marker)+1)
+			para = append(para, strings.TrimSpace(line[len(marker):]))
+		case para == nil:
+			first = strings.Repeat(" ", self.Indent)
+			rest = strings.Repeat(" ", self.HangingIndent)
+			para = append(para, line)
+		default:
+			para = append(para, line)
+		}
+	}
+	flush()
+	return
+}
+
+// paragraph appends text wrapped using w and formatted to out, with the
+// first line led by first and others by rest, and returns out.
+func (self TextFormatter) paragraph(out []string, w *textWrapper, text, first, rest string) []string {
+	var (
+		prefix = DisplayWidth(self.Prefix)
+		widths = [2]int{self.Width - prefix - DisplayWidth(first), self.Width - prefix - DisplayWidth(rest)}
+		lines  = []string{text}
+	)
+	if self.Width > 0 {
+		w.first, w.cols, w.out = max(widths[0], 1), max(widths[1], 1), nil
+		w.wrap(text)
+		lines = w.out
+	}
+	for i, line := range lines {
+		var lead, width = rest, widths[1]
+		if i == 0 {
+			lead, width = first, widths[0]
+		}
+		if self.Width > 0 {
+			line = alignLine(line, width, self.Align, i == len(lines)-1)
+		}
+		out = append(out, self.Prefix+lead+line)
+	}
+	return out
+}
+
+// alignLine returns line aligned within width. Lines wider than width are
+// returned unmodified.
+func alignLine(line string, width int, align Alignment, last bool) string {
+	var pad = width - DisplayWidth(line)
+	if pad <= 0 {
+		return line
+	}
+	switch align {
+	case AlignRight:
+		return strings.Repeat(" ", pad) + line
+	case AlignCenter:
+		return strings.Repeat(" ", pad/2) + line
+	case AlignJustify:
+		if !last {
+			return justifyLine(line, width)
+		}
+	}
+	return line
+}
+
+// justifyLine returns line stretched to width by widening spaces between
+// words. A line of a single word is returned unmodified.
+func justifyLine(line string, width int) string {
+	var words = strings.Fields(line)
+	if len(words) < 2 {
+		return line
+	}
+	var (
+		gaps  = len(words) - 1
+		extra = width - DisplayWidth(strings.Join(words, " "))
+		buf   = make([]byte, 0, len(line)+extra)
+	)
+	for i, word := range words {
+		if i > 0 {
+			var n = 1 + extra/gaps
+			if i <= extra%gaps {
+				n++
+			}
+			for ; n > 0; n-- {
+				buf = append(buf, ' ')
+			}
+		}
+		buf = append(buf, word...)
+	}
+	return UnsafeString(buf)
+}
+
+// listItem returns the indentation and the marker of a list item line and
+// true or 0, "" and false if line is not a list item.
+func listItem(line string) (indent int, marker string, ok bool) {
+	for indent < len(line) && line[indent] == ' ' {
+		indent++
+	}
+	var s = line[indent:]
+	switch {
+	case strings.HasPrefix(s, "• "):
+		marker = "•"
+	case len(s) > 1 && strings.IndexByte("-*+", s[0]) >= 0 && s[1] == ' ':
+		marker = s[:1]
+	default:
+		var n = 0
+		for n < len(s) && n < 9 && IsDigit(s[n]) {
+			n++
+		}
+		if n == 0 || n+1 >= len(s) || s[n] != '.' && s[n] != ')' || s[n+1] != ' ' {
+			return 0, "", false
+		}
+		marker = s[:n+1]
+	}
+	return indent, marker, true
+}
@@ -0,0 +1,102 @@
+package strutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTextFormatter(t *testing.T) {
+	type formatSample struct {
+		formatter TextFormatter
+		text      string
+		out       []string
+	}
+	samples := []formatSample{
+		{TextFormatter{Width: 20}, "", nil},
+		{TextFormatter{Width: 20}, "one two\nthree four five six", []string{
+			"one two three four",
+			"five six",
+		}},
+		{TextFormatter{Width: 20}, "first\n\n\nsecond  \n  paragraph", []string{
+			"first",
+			"",
+			"",
+			"second paragraph",
+		}},
+		{TextFormatter{Width: 12, Indent: 4}, "one two three four", []string{
+			"    one two",
+			"three four",
+		}},
+		{TextFormatter{Width: 12, HangingIndent: 2}, "one two three four five", []string{
+			"one two",
+			"  three four",
+			"  five",
+		}},
+		{TextFormatter{Width: 12, Prefix: "// "}, "one two three\n\nfour", []string{
+			"// one two",
+			"// three",
+			"//",
+			"// four",
+		}},
+		{TextFormatter{Width: 10, Align: AlignRight}, "one two three", []string{
+			"   one two",
+			"     three",
+		}},
+		{TextFormatter{Width: 10, Align: AlignCenter}, "one two three", []string{
+			" one two",
+			"  three",
+		}},
+		{TextFormatter{Width: 12, Align: AlignJustify}, "a bb ccc dddd eeeee ff", []string{
+			"a   bb   ccc",
+			"dddd   eeeee",
+			"ff",
+		}},
+		{TextFormatter{Width: 11, Align: AlignJustify}, "one two three", []string{
+			"one     two",
+			"three",
+		}},
+		{TextFormatter{Width: 14, Align: AlignJustify}, "a b c d e f g h", []string{
+			"a  b c d e f g",
+			"h",
+		}},
+		{TextFormatter{Width: 16}, "Items:\n- first item is long\n  continued\n- second\n  1. nested item text\n  2) next", []string{
+			"Items:",
+			"- first item is",
+			"  long continued",
+			"- second",
+			"  1. nested item",
+			"     text",
+			"  2) next",
+		}},
+		{TextFormatter{Width: 12, Indent: 2, Prefix: "> "}, "• bullet point text", []string{
+			">   • bullet",
+			">     point",
+			">     text",
+		}},
+		{TextFormatter{Width: 8, Align: AlignRight}, "日本語 テキスト", []string{
+			"  日本語",
+			"テキスト",
+		}},
+		{TextFormatter{Prefix: "# ", Indent: 1}, "no width\nhere", []string{
+			"#  no width here",
+		}},
+		{TextFormatter{Width: 6, Force: true}, "abcdefghij", []string{
+			"abcdef",
+			"ghij",
+		}},
+		{TextFormatter{Width: 10}, "12 monkeys\n-5 degrees", []string{
+			"12 monkeys",
+			"-5 degrees",
+		}},
+	}
+
+	for _, sample := range samples {
+		if out := sample.formatter.Lines(sample.text); !reflect.DeepEqual(out, sample.out) {
+			t.Errorf("got %q formatting %q %+v, expected %q", out, sample.text, sample.formatter, sample.out)
+		}
+	}
+
+	if out := (TextFormatter{Width: 7}).Format("one two three"); out != "one two\nthree" {
+		t.Errorf("Format failed: %q", out)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/vedranvuk/strutils
 
-go 1.21.6
+go 1.23
@@ -0,0 +1,309 @@
+// Copyright 2026 Vedran Vuk. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package strutils
+
+import (
+	"go/token"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CollisionStrategy specifies how [NameMapper] resolves distinct names that
+// map to the same output.
+type CollisionStrategy int
+
+const (
+	// ErrorOnCollision makes [NameMapper.Map] return a [*NameCollisionError]
+	// on first collision.
+	ErrorOnCollision CollisionStrategy = iota
+	// SuffixOnCollision appends the lowest numeric suffix starting from 2 to
+	// a colliding output that makes it unique among all outputs. The first
+	// name in input order keeps the unsuffixed output.
+	//
+	// Suffix is separated by an underscore or dash for snake_case and
+	// kebab-case mappings respectively and appended directly otherwise.
+	SuffixOnCollision
+)
+
+// NameCollisionError is returned by [NameMapper.Map] if two distinct names
+// map to the same output and strategy is [ErrorOnCollision].
+type NameCollisionError struct {
+	// Name is the name that was mapped first.
+	Name string
+	// Other is the name that collided with Name.
+	Other string
+	// Output is the output both names map to.
+	Output string
+}
+
+// Error implements error on NameCollisionError.
+func (self *NameCollisionError) Error() string {
+	return "names " + strconv.Quote(self.Name) + " and " + strconv.Quote(self.Other) +
+		" both map to " + strconv.Quote(self.Output)
+}
+
+// NameMapper maps a set of names through a [CaseMapping] and detects
+// distinct names that map to the same output.
+type NameMapper struct {
+	// Mapping is the case mapping applied to names.
+	Mapping CaseMapping
+	// Strategy specifies how collisions are resolved.
+	//
+	// Default: ErrorOnCollision
+	Strategy CollisionStrategy
+}
+
+// NameMap is a bidirectional mapping table of names produced by
+// [NameMapper.Map].
+type NameMap struct {
+	// Forward maps input names to outputs.
+	Forward map[string]string
+	// Reverse maps outputs to input names.
+	Reverse map[string]string
+}
+
+// Map maps names and returns the mapping table or an error.
+//
+// Duplicate names are not collisions and are mapped once.
+func (self NameMapper) Map(names ...string) (*NameMap, error) {
+
+	var (
+		out = &NameMap{
+			Forward: make(map[string]string, len(names)),
+			Reverse: make(map[string]string, len(names)),
+		}
+		mapped = make([]string, len(names))
+		taken  = make(map[string]bool, len(names))
+	)
+
+	// Outputs of all names are reserved up front so that a suffixed output
+	// never takes the natural output of a name that follows it.
+	for i, name := range names {
+		mapped[i] = self.Mapping.Map(name)
+		taken[mapped[i]] = true
+	}
+
+	for i, name := range names {
+		if _, exists := out.Forward[name]; exists {
+			continue
+		}
+		var output = mapped[i]
+		if other, exists := out.Reverse[output]; exists {
+			if self.Strategy != SuffixOnCollision {
+				return nil, &NameCollisionError{Name: other, Other: name, Output: output}
+			}
+			output = self.suffix(output, taken)
+			taken[output] = true
+		}
+		out.Forward[name] = output
+		out.Reverse[output] = name
+	}
+
+	return out, nil
+}
+
+// suffix returns output with the lowest numeric suffix not in taken.
+func (self NameMapper) suffix(output string, taken map[string]bool) string {
+	var (
+		buf = make([]byte, 0, len(output)+4)
+		n   = 2
+	)
+	for ; ; n++ {
+		buf = append(buf[:0], output...)
+		switch self.Mapping {
+		case SnakeMapping:
+			buf = append(buf, underscoreByte)
+		case KebabMapping:
+			buf = append(buf, dashByte)
+		}
+		buf = strconv.AppendInt(buf, int64(n), 10)
+		if !taken[string(buf)] {
+			return string(buf)
+		}
+	}
+}
+
+// GoIdentifier returns s converted to a valid Go identifier using case
+// mapping m. If exported is true the identifier is exported, otherwise it is
+// unexported.
+//
+// Accented latin letters are transliterated to their ASCII spelling before
+// mapping, e.g. "café" becomes "cafe". Leading ASCII digits are spelled out
+// as separate words, so "2fa-enabled" with [PascalMapping] becomes
+// "TwoFaEnabled". Words are split and cased as by m, but unlike
+// [CaseMapping.Map] other Unicode letters and digits are kept and cased, so
+// "Ωmega" with [PascalMapping] stays "Ωmega". After mapping dashes and spaces
+// are replaced with an underscore and any other rune that is not valid in an
+// identifier is dropped. If the first rune cannot be cased as requested an
+// "X" or "x" prefix is added.
+//
+// Names that are Go keywords, predeclared identifiers, such as "type" or
+// "string", or the blank identifier "_" are escaped by appending an
+// underscore.
+//
+// If nothing remains of s result is "X" if exported or "x" otherwise.
+func GoIdentifier(s string, m CaseMapping, exported bool) string {
+
+	s = mapIdentifier(spellLeadingDigits(transliterate(s)), m)
+
+	var (
+		b       = make([]byte, 0, len(s)+1)
+		pending = false
+	)
+	for _, r := range s {
+		switch {
+		case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			if pending && len(b) > 0 && b[len(b)-1] != '_' {
+				b = append(b, '_')
+			}
+			b = utf8.AppendRune(b, r)
+			pending = false
+		case r == '-' || unicode.IsSpace(r):
+			pending = true
+		}
+	}
+
+	var prefix = "x"
+	if exported {
+		prefix = "X"
+	}
+	if len(b) == 0 {
+		return prefix
+	}
+
+	var first, size = utf8.DecodeRune(b)
+	switch {
+	case unicode.IsDigit(first):
+		b = append([]byte(prefix), b...)
+	case exported && !unicode.IsUpper(first):
+		if upper := unicode.ToUpper(first); unicode.IsUpper(upper) {
+			b = append(utf8.AppendRune(nil, upper), b[size:]...)
+		} else {
+			b = append([]byte(prefix), b...)
+		}
+	case !exported && unicode.IsUpper(first):
+		b = append(utf8.AppendRune(nil, unicode.ToLower(first)), b[size:]...)
+	}
+
+	var name = UnsafeString(b)
+	if name == "_" || token.IsKeyword(name) || goPredeclared[name] {
+		return name + "_"
+	}
+	return name
+}
+
+// mapIdentifier returns s mapped with m like [CaseMapping.Map] but keeping
+// and casing Unicode letters and digits which m drops.
+func mapIdentifier(s string, m CaseMapping) string {
+	var sep rune
+	switch m {
+	case PascalMapping, CamelMapping:
+	case SnakeMapping:
+		sep = underscoreByte
+	case KebabMapping:
+		sep = dashByte
+	default:
+		return m.Map(s)
+	}
+	var (
+		b     = make([]byte, 0, len(s)+len(s)/2)
+		words = 0
+	)
+	for start, end := nextIdentifierWord(s, 0, sep == 0); start < end; start, end = nextIdentifierWord(s, end, sep == 0) {
+		if sep != 0 && words > 0 {
+			b = utf8.AppendRune(b, sep)
+		}
+		for i, r := range s[start:end] {
+			if i == 0 && sep == 0 && (words > 0 || m == PascalMapping) {
+				r = unicode.ToUpper(r)
+			} else {
+				r = unicode.ToLower(r)
+			}
+			b = utf8.AppendRune(b, r)
+		}
+		words++
+	}
+	return UnsafeString(b)
+}
+
+// nextIdentifierWord returns the start and end index of the next word in s,
+// scanning from i, by rules of [WordRules] with CaseBoundary and, if digits
+// is true, DigitBoundary applied to Unicode letters and digits. If there are
+// no more words in s start and end are equal.
+func nextIdentifierWord(s string, i int, digits bool) (start, end int) {
+	var isWord = func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+	}
+	for i < len(s) {
+		var r, size = utf8.DecodeRuneInString(s[i:])
+		if isWord(r) {
+			break
+		}
+		i += size
+	}
+	if start = i; i == len(s) {
+		return i, i
+	}
+	var (
+		p, size = utf8.DecodeRuneInString(s[i:])
+		lower   = unicode.IsLower(p)
+	)
+	for i += size; i < len(s); i += size {
+		var c rune
+		if c, size = utf8.DecodeRuneInString(s[i:]); !isWord(c) {
+			break
+		}
+		if digits && (unicode.IsDigit(c) && unicode.IsLetter(p) || unicode.IsLetter(c) && unicode.IsDigit(p)) {
+			break
+		}
+		if unicode.IsUpper(c) && lower {
+			break
+		}
+		if unicode.IsLower(c) {
+			lower = true
+		}
+		p = c
+	}
+	return start, i
+}
+
+// digitNames are ASCII digit names used to spell out leading digits.
+var digitNames = [10]string{
+	"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+}
+
+// spellLeadingDigits returns s with the leading ASCII digits, ignoring any
+// leading bytes that are not letters or digits, replaced with their names
+// separated by spaces.
+func spellLeadingDigits(s string) string {
+	var i = 0
+	for i < len(s) && !IsAlphanumeric(s[i]) {
+		i++
+	}
+	if i == len(s) || !IsDigit(s[i]) {
+		return s
+	}
+	var b = make([]byte, 0, len(s)+32)
+	for ; i < len(s) && IsDigit(s[i]); i++ {
+		b = append(b, digitNames[s[i]-'0']...)
+		b = append(b, ' ')
+	}
+	return UnsafeString(append(b, s[i:]...))
+}
+
+// goPredeclared is a set of Go predeclared identifiers.
+var goPredeclared = map[string]bool{
+	"any": true, "bool": true, "byte": true, "comparable": true,
+	"complex64": true, "complex128": true, "error": true, "float32": true,
+	"float64": true, "int": true, "int8": true, "int16": true, "int32": true,
+	"int64": true, "rune": true, "string": true, "uint": true, "uint8": true,
+	"uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"true": true, "false": true, "iota": true, "nil": true,
+	"append": true, "cap": true, "clear": true, "close": true,
+	"complex": true, "copy": true, "delete": true, "imag": true, "len": true,
+	"make": true, "max": true, "min": true, "new": true, "panic": true,
+	"print": true, "println": true, "real": true, "recover": true,
+}
@@ -0,0 +1,113 @@
+package strutils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNameMapper(t *testing.T) {
+	var mapper = NameMapper{Mapping: PascalMapping, Strategy: SuffixOnCollision}
+
+	out, err := mapper.Map("user-id", "user_id", "name", "user_id", "UserId2", "userId")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var forward = map[string]string{
+		"user-id": "UserId",
+		"user_id": "UserId3",
+		"name":    "Name",
+		"UserId2": "UserId2",
+		"userId":  "UserId4",
+	}
+	if !reflect.DeepEqual(out.Forward, forward) {
+		t.Errorf("got %v, expected %v", out.Forward, forward)
+	}
+	for name, output := range forward {
+		if out.Reverse[output] != name {
+			t.Errorf("got %q from reverse %q, expected %q", out.Reverse[output], output, name)
+		}
+	}
+}
+
+func TestNameMapperSeparatorSuffix(t *testing.T) {
+	var mapper = NameMapper{Mapping: SnakeMapping, Strategy: SuffixOnCollision}
+
+	out, err := mapper.Map("userId", "user-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Forward["user-id"] != "user_id_2" {
+		t.Errorf("got %q, expected %q", out.Forward["user-id"], "user_id_2")
+	}
+}
+
+func TestNameMapperError(t *testing.T) {
+	var mapper = NameMapper{Mapping: KebabMapping}
+
+	_, err := mapper.Map("user-id", "userId")
+
+	var collision *NameCollisionError
+	if !errors.As(err, &collision) {
+		t.Fatalf("expected NameCollisionError, got %v", err)
+	}
+	if collision.Name != "user-id" || collision.Other != "userId" || collision.Output != "user-id" {
+		t.Errorf("unexpected collision: %+v", collision)
+	}
+
+	if _, err = mapper.Map("user-id", "user-id", "name"); err != nil {
+		t.Errorf("unexpected error for duplicate names: %v", err)
+	}
+}
+
+func TestGoIdentifier(t *testing.T) {
+	type identSample struct {
+		str      string
+		mapping  CaseMapping
+		exported bool
+		out      string
+	}
+	samples := []identSample{
+		{"2fa-enabled", PascalMapping, true, "TwoFaEnabled"},
+		{"2fa-enabled", CamelMapping, false, "twoFaEnabled"},
+		{"2fa-enabled", SnakeMapping, false, "two_fa_enabled"},
+		{"2fa-enabled", SnakeMapping, true, "Two_fa_enabled"},
+		{"user-id", KebabMapping, false, "user_id"},
+		{"12 monkeys", CamelMapping, false, "oneTwoMonkeys"},
+		{"type", CamelMapping, false, "type_"},
+		{"type", PascalMapping, true, "Type"},
+		{"class", CamelMapping, false, "class"},
+		{"string", SnakeMapping, false, "string_"},
+		{"len", NoMapping, false, "len_"},
+		{"café au lait", PascalMapping, true, "CafeAuLait"},
+		{"Straße", SnakeMapping, false, "strasse"},
+		{"naïve-name", NoMapping, false, "naive_name"},
+		{"日本語", NoMapping, false, "日本語"},
+		{"日本語", NoMapping, true, "X日本語"},
+		{"ǆemal", NoMapping, true, "Ǆemal"},
+		{"_private", NoMapping, false, "_private"},
+		{"_private", NoMapping, true, "X_private"},
+		{"٣abc", NoMapping, false, "x٣abc"},
+		{"a.b$c", NoMapping, false, "abc"},
+		{"Ωmega", PascalMapping, true, "Ωmega"},
+		{"Ωmega-Δelta", CamelMapping, false, "ωmegaΔelta"},
+		{"Ωmega-Δelta", SnakeMapping, false, "ωmega_δelta"},
+		{"日本", PascalMapping, true, "X日本"},
+		{"中文", PascalMapping, true, "X中文"},
+		{"привет мир", CamelMapping, false, "приветМир"},
+		{"x日本y", PascalMapping, true, "X日本y"},
+		{"HTTPServer2go", SnakeMapping, false, "httpserver2go"},
+		{"_", NoMapping, false, "__"},
+		{"_", NoMapping, true, "X_"},
+		{"  -- ", PascalMapping, true, "X"},
+		{"", CamelMapping, false, "x"},
+	}
+
+	for _, sample := range samples {
+		out := GoIdentifier(sample.str, sample.mapping, sample.exported)
+		if out != sample.out {
+			t.Errorf("got %q from %q, expected %q", out, sample.str, sample.out)
+		}
+	}
+}
@@ -0,0 +1,371 @@
+// Copyright 2026 Vedran Vuk. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package strutils
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"iter"
+	"unicode/utf8"
+)
+
+// SearchOptions specify Searcher options.
+type SearchOptions struct {
+	// Fold, if true, matches patterns under simple Unicode case folding.
+	Fold bool
+
+	// LeftmostLongest, if true, reports non-overlapping matches only where
+	// of all matches that overlap the one that starts first is reported and
+	// of those that start at the same offset the longest one is reported.
+	// Otherwise all matches of all patterns are reported, including those
+	// that overlap.
+	LeftmostLongest bool
+}
+
+// SearchMatch is a match of a Searcher pattern.
+type SearchMatch struct {
+	// Pattern is the index of the matched pattern.
+	Pattern int
+	// Start and End are byte offsets of the match in the input.
+	Start, End int
+}
+
+// Searcher searches for many patterns at once in a single pass over the
+// input using the Aho-Corasick algorithm.
+//
+// Matches are reported in order of their end offset and matches that end at
+// the same offset in order of their start offset. Empty patterns never match.
+//
+// A Searcher is safe for concurrent use.
+type Searcher struct {
+	options  SearchOptions
+	patterns []string
+	// lengths are lengths of canonical patterns.
+	lengths []int
+	// maxLen is the length of the longest canonical pattern.
+	maxLen int
+	// classes maps bytes to byte classes, 0 being the class of bytes that
+	// appear in no pattern.
+	classes [256]byte
+	// stride is the number of byte classes.
+	stride int
+	// delta is the transition table indexed by state*stride+class.
+	delta []int32
+	// depth is the length of the pattern prefix a state represents.
+	depth []int
+	// outputs are pattern indexes that match at a state, longest first.
+	outputs [][]int
+}
+
+// NewSearcher returns a new Searcher for patterns.
+func NewSearcher(options SearchOptions, patterns ...string) *Searcher {
+	var (
+		self      = &Searcher{options: options, patterns: patterns}
+		canonical = make([]string, len(patterns))
+	)
+	self.lengths = make([]int, len(patterns))
+	for i, pattern := range patterns {
+		if options.Fold {
+			pattern = foldString(pattern)
+		}
+		canonical[i] = pattern
+		self.lengths[i] = len(pattern)
+		self.maxLen = max(self.maxLen, len(pattern))
+		for j := 0; j < len(pattern); j++ {
+			self.classes[pattern[j]] = 1
+		}
+	}
+	self.stride = 1
+	for c := range self.classes {
+		if self.classes[c] != 0 {
+			self.classes[c] = byte(self.stride)
+			self.stride++
+		}
+	}
+	self.build(canonical)
+	return self
+}
+
+// Len returns the number of patterns.
+func (self *Searcher) Len() int { return len(self.patterns) }
+
+// Pattern returns the pattern at index i.
+func (self *Searcher) Pattern(i int) string { return self.patterns[i] }
+
+// All returns an iterator over all matches in s.
+func (self *Searcher) All(s string) iter.Seq[SearchMatch] {
+	return func(yield func(SearchMatch) bool) {
+		var scan = self.newScan()
+		if !self.options.Fold {
+			for i := 0; i < len(s); i++ {
+				scan.step(s[i], i)
+				if !scan.emit(i+1, yield) {
+					return
+				}
+			}
+			scan.flush(yield)
+			return
+		}
+		var buf [utf8.UTFMax]byte
+		for i, r := range s {
+			var size = utf8.RuneLen(r)
+			if r == utf8.RuneError {
+				_, size = utf8.DecodeRuneInString(s[i:])
+			}
+			for _, c := range utf8.AppendRune(buf[:0], foldRune(r)) {
+				scan.step(c, i)
+			}
+			if !scan.emit(i+size, yield) {
+				return
+			}
+		}
+		scan.flush(yield)
+	}
+}
+
+// FindAll returns all matches in s or nil if there are none.
+func (self *Searcher) FindAll(s string) (out []SearchMatch) {
+	for m := range self.All(s) {
+		out = append(out, m)
+	}
+	return
+}
+
+// Find returns the first match in s and true or an empty match and false if
+// no pattern matches s.
+func (self *Searcher) Find(s string) (match SearchMatch, found bool) {
+	for m := range self.All(s) {
+		return m, true
+	}
+	return
+}
+
+// FindReader reads r until EOF and calls fn for every match where offsets
+// are relative to the start of r. It stops when fn returns false.
+// It returns a read error other than io.EOF.
+//
+// Matches are reported as soon as they are known not to be superseded by a
+// match yet to be read.
+func (self *Searcher) FindReader(r io.Reader, fn func(SearchMatch) bool) error {
+	var scan = self.newScan()
+	if !self.options.Fold {
+		var buf [4096]byte
+		for off := 0; ; {
+			n, err := r.Read(buf[:])
+			for i := 0; i < n; i++ {
+				scan.step(buf[i], off+i)
+				if !scan.emit(off+i+1, fn) {
+					return nil
+				}
+			}
+			off += n
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					scan.flush(fn)
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	var (
+		br  = bufio.NewReader(r)
+		buf [utf8.UTFMax]byte
+	)
+	for off := 0; ; {
+		var c, size, err = br.ReadRune()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				scan.flush(fn)
+				return nil
+			}
+			return err
+		}
+		for _, b := range utf8.AppendRune(buf[:0], foldRune(c)) {
+			scan.step(b, off)
+		}
+		if off += size; !scan.emit(off, fn) {
+			return nil
+		}
+	}
+}
+
+// build builds the automaton from canonical patterns.
+func (self *Searcher) build(patterns []string) {
+	var newState = func(depth int) int32 {
+		for i := 0; i < self.stride; i++ {
+			self.delta = append(self.delta, -1)
+		}
+		self.depth = append(self.depth, depth)
+		self.outputs = append(self.outputs, nil)
+		return int32(len(self.depth) - 1)
+	}
+	newState(0)
+
+	for i, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		var state int32
+		for j := 0; j < len(pattern); j++ {
+			var t = int(state)*self.stride + int(self.classes[pattern[j]])
+			if self.delta[t] < 0 {
+				var next = newState(j + 1)
+				self.delta[t] = next
+			}
+			state = self.delta[t]
+		}
+		self.outputs[state] = append(self.outputs[state], i)
+	}
+
+	// Compute failure links breadth first and complete missing transitions
+	// with those of the failure state so that search never backtracks.
+	var (
+		fail  = make([]int32, len(self.depth))
+		queue = make([]int32, 0, len(self.depth))
+	)
+	for c := 0; c < self.stride; c++ {
+		if next := self.delta[c]; next < 0 {
+			self.delta[c] = 0
+		} else {
+			queue = append(queue, next)
+		}
+	}
+	for len(queue) > 0 {
+		var state = queue[0]
+		queue = queue[1:]
+		self.outputs[state] = append(self.outputs[state], self.outputs[fail[state]]...)
+		for c := 0; c < self.stride; c++ {
+			var (
+				t       = int(state)*self.stride + c
+				through = self.delta[int(fail[state])*self.stride+c]
+			)
+			if next := self.delta[t]; next < 0 {
+				self.delta[t] = through
+			} else {
+				fail[next] = through
+				queue = append(queue, next)
+			}
+		}
+	}
+}
+
+// newScan returns a new search scan.
+func (self *Searcher) newScan() *searchScan {
+	var scan = &searchScan{searcher: self}
+	if self.options.Fold && self.maxLen > 0 {
+		scan.ring = make([]int, self.maxLen)
+	}
+	return scan
+}
+
+// searchScan is the state of a search in progress.
+type searchScan struct {
+	searcher *Searcher
+	// state is the current automaton state.
+	state int32
+	// pos is the number of canonical bytes scanned.
+	pos int
+	// ring maps recent canonical offsets to input offsets in fold mode.
+	ring []int
+	// pending are leftmost-longest candidates not yet reported.
+	pending []SearchMatch
+	// floor is the offset before which leftmost-longest matches may not
+	// start.
+	floor int
+}
+
+// step advances the scan by canonical byte c of the input unit at input
+// offset off.
+func (self *searchScan) step(c byte, off int) {
+	if self.ring != nil {
+		self.ring[self.pos%len(self.ring)] = off
+	}
+	var s = self.searcher
+	self.state = s.delta[int(self.state)*s.stride+int(s.classes[c])]
+	self.pos++
+}
+
+// offset returns the input offset of canonical offset pos which must be
+// within the last maxLen scanned bytes.
+func (self *searchScan) offset(pos int) int {
+	if self.ring == nil {
+		return pos
+	}
+	return self.ring[pos%len(self.ring)]
+}
+
+// emit reports matches that end at input offset end, at the end of an input
+// unit. It returns false if yield returned false.
+func (self *searchScan) emit(end int, yield func(SearchMatch) bool) bool {
+	var s = self.searcher
+	for _, p := range s.outputs[self.state] {
+		var m = SearchMatch{Pattern: p, Start: self.offset(self.pos - s.lengths[p]), End: end}
+		if !s.options.LeftmostLongest {
+			if !yield(m) {
+				return false
+			}
+			continue
+		}
+		if m.Start >= self.floor {
+			self.pending = append(self.pending, m)
+		}
+	}
+	if len(self.pending) == 0 {
+		return true
+	}
+	// A match that starts before the start of the pattern prefix being
+	// matched can no longer be superseded.
+	var limit = end
+	if depth := s.depth[self.state]; depth > 0 {
+		limit = self.offset(self.pos - depth)
+	}
+	return self.report(limit, yield)
+}
+
+// flush reports all pending matches at the end of input.
+func (self *searchScan) flush(yield func(SearchMatch) bool) {
+	self.report(int(^uint(0)>>1), yield)
+}
+
+// report reports pending leftmost-longest matches that start before limit.
+// It returns false if yield returned false.
+func (self *searchScan) report(limit int, yield func(SearchMatch) bool) bool {
+	for len(self.pending) > 0 {
+		var best = self.pending[0]
+		for _, m := range self.pending[1:] {
+			if m.Start < best.Start || m.Start == best.Start && m.End > best.End {
+				best = m
+			}
+		}
+		if best.Start >= limit {
+			break
+		}
+		if !yield(best) {
+			return false
+		}
+		self.floor = best.End
+		var n = 0
+		for _, m := range self.pending {
+			if m.Start >= self.floor {
+				self.pending[n] = m
+				n++
+			}
+		}
+		self.pending = self.pending[:n]
+	}
+	return true
+}
+
+// foldString returns s with every rune replaced by its canonical case folded
+// form.
+func foldString(s string) string {
+	var buf = make([]byte, 0, len(s))
+	for _, r := range s {
+		buf = utf8.AppendRune(buf, foldRune(r))
+	}
+	return UnsafeString(buf)
+}
@@ -0,0 +1,198 @@
+package strutils
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+)
+
+func TestSearcher(t *testing.T) {
+	type searchSample struct {
+		options  SearchOptions
+		patterns []string
+		str      string
+		matches  []SearchMatch
+	}
+	samples := []searchSample{
+		{SearchOptions{}, nil, "abc", nil},
+		{SearchOptions{}, []string{""}, "abc", nil},
+		{SearchOptions{}, []string{"he", "she", "his", "hers"}, "ushers", []SearchMatch{
+			{1, 1, 4}, {0, 2, 4}, {3, 2, 6},
+		}},
+		{SearchOptions{}, []string{"aa"}, "aaaa", []SearchMatch{
+			{0, 0, 2}, {0, 1, 3}, {0, 2, 4},
+		}},
+		{SearchOptions{}, []string{"x", "x"}, "x", []SearchMatch{
+			{0, 0, 1}, {1, 0, 1},
+		}},
+		{SearchOptions{}, []string{"ERROR"}, "error ERROR", []SearchMatch{
+			{0, 6, 11},
+		}},
+		{SearchOptions{Fold: true}, []string{"ERROR", "warn"}, "error: Warning", []SearchMatch{
+			{0, 0, 5}, {1, 7, 11},
+		}},
+		{SearchOptions{Fold: true}, []string{"straße"}, "STRAẞE!", []SearchMatch{
+			{0, 0, 8},
+		}},
+		{SearchOptions{Fold: true}, []string{"Ž"}, "ažb\xffž", []SearchMatch{
+			{0, 1, 3}, {0, 5, 7},
+		}},
+		{SearchOptions{LeftmostLongest: true}, []string{"he", "she", "his", "hers"}, "ushers", []SearchMatch{
+			{1, 1, 4},
+		}},
+		{SearchOptions{LeftmostLongest: true}, []string{"a", "ab", "abcd"}, "abcabcd", []SearchMatch{
+			{1, 0, 2}, {2, 3, 7},
+		}},
+		{SearchOptions{LeftmostLongest: true}, []string{"abcde", "ab", "cd"}, "abcdx", []SearchMatch{
+			{1, 0, 2}, {2, 2, 4},
+		}},
+		{SearchOptions{LeftmostLongest: true}, []string{"aa"}, "aaaaa", []SearchMatch{
+			{0, 0, 2}, {0, 2, 4},
+		}},
+		{SearchOptions{LeftmostLongest: true, Fold: true}, []string{"ß", "SSx"}, "ẞ ssX", []SearchMatch{
+			{0, 0, 3}, {1, 4, 7},
+		}},
+	}
+
+	for _, sample := range samples {
+		var s = NewSearcher(sample.options, sample.patterns...)
+		if matches := s.FindAll(sample.str); !slices.Equal(matches, sample.matches) {
+			t.Errorf("got %v searching %q for %q %+v, expected %v", matches, sample.str, sample.patterns, sample.options, sample.matches)
+		}
+		match, found := s.Find(sample.str)
+		if found != (len(sample.matches) > 0) || found && match != sample.matches[0] {
+			t.Errorf("Find failed for %q in %q", sample.patterns, sample.str)
+		}
+		var streamed []SearchMatch
+		if err := s.FindReader(iotest.OneByteReader(strings.NewReader(sample.str)), func(m SearchMatch) bool {
+			streamed = append(streamed, m)
+			return true
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(streamed, sample.matches) {
+			t.Errorf("got %v streaming %q for %q %+v, expected %v", streamed, sample.str, sample.patterns, sample.options, sample.matches)
+		}
+	}
+
+	var s = NewSearcher(SearchOptions{}, "a", "b")
+	if s.Len() != 2 || s.Pattern(1) != "b" {
+		t.Error("Len or Pattern failed")
+	}
+	var n = 0
+	if err := s.FindReader(strings.NewReader("aaaa"), func(SearchMatch) bool { n++; return n < 2 }); err != nil || n != 2 {
+		t.Errorf("FindReader did not stop, got %d calls, %v", n, err)
+	}
+	var errRead = errors.New("read error")
+	if err := s.FindReader(iotest.ErrReader(errRead), func(SearchMatch) bool { return true }); !errors.Is(err, errRead) {
+		t.Errorf("got %v, expected read error", err)
+	}
+}
+
+// naiveSearch returns matches of patterns in s found by brute force.
+func naiveSearch(options SearchOptions, patterns []string, s string) (out []SearchMatch) {
+	for end := 0; end <= len(s); end++ {
+		for start := 0; start < end; start++ {
+			for i, pattern := range patterns {
+				if pattern == "" {
+					continue
+				}
+				if options.Fold {
+					if !utf8.RuneStart(s[start]) || end < len(s) && !utf8.RuneStart(s[end]) {
+						continue
+					}
+					if n, ok := prefixFold(s[start:end], pattern); !ok || n != end-start {
+						continue
+					}
+				} else if s[start:end] != pattern {
+					continue
+				}
+				out = append(out, SearchMatch{i, start, end})
+			}
+		}
+	}
+	if !options.LeftmostLongest {
+		return
+	}
+	slices.SortStableFunc(out, func(a, b SearchMatch) int {
+		if a.Start != b.Start {
+			return a.Start - b.Start
+		}
+		return b.End - a.End
+	})
+	var filtered []SearchMatch
+	for _, m := range out {
+		if len(filtered) == 0 || m.Start >= filtered[len(filtered)-1].End {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
+
+// TestSearcherAgrees checks that Searcher agrees with naive search on random
+// patterns and strings.
+func TestSearcherAgrees(t *testing.T) {
+	const chars = "abAB-ßẞ"
+	var (
+		rnd     = rand.New(rand.NewSource(1))
+		options = []SearchOptions{{}, {Fold: true}, {LeftmostLongest: true}, {Fold: true, LeftmostLongest: true}}
+		random  = func(max int) string {
+			var r = []rune(chars)
+			var b = make([]rune, rnd.Intn(max+1))
+			for i := range b {
+				b[i] = r[rnd.Intn(len(r))]
+			}
+			return string(b)
+		}
+	)
+	for i := 0; i < 1000; i++ {
+		var patterns = make([]string, 1+rnd.Intn(5))
+		for j := range patterns {
+			patterns[j] = random(4)
+		}
+		var text = random(16)
+		for _, opts := range options {
+			var (
+				got      = NewSearcher(opts, patterns...).FindAll(text)
+				expected = naiveSearch(opts, patterns, text)
+			)
+			if !slices.Equal(got, expected) {
+				t.Fatalf("got %v searching %q for %q %+v, expected %v", got, text, patterns, opts, expected)
+			}
+		}
+	}
+}
+
+// searcherBenchmark returns benchmark keywords and a log line.
+func searcherBenchmark() (keywords []string, line string) {
+	for i := 0; i < 500; i++ {
+		keywords = append(keywords, "keyword"+strconv.Itoa(i))
+	}
+	line = strings.Repeat("2026-01-01 12:00:00 INFO request served keyword42 in 12ms ", 4)
+	return
+}
+
+func BenchmarkSearcher(b *testing.B) {
+	var keywords, line = searcherBenchmark()
+	var s = NewSearcher(SearchOptions{}, keywords...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for range s.All(line) {
+		}
+	}
+}
+
+func BenchmarkSearcherNaive(b *testing.B) {
+	var keywords, line = searcherBenchmark()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, keyword := range keywords {
+			Indexes(line, keyword)
+		}
+	}
+}
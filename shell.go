@@ -0,0 +1,269 @@
+// Copyright 2026 Vedran Vuk. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package strutils
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ShellError describes a syntax error in a shell command line.
+type ShellError struct {
+	// Input is the malformed command line.
+	Input string
+	// Pos is the byte offset of the error in Input.
+	Pos int
+	// Message describes the error.
+	Message string
+}
+
+// Error implements error on ShellError.
+func (self *ShellError) Error() string {
+	return "shell: " + self.Message + " at " + strconv.Itoa(self.Pos) + " in " + strconv.Quote(self.Input)
+}
+
+// SplitShell splits command line s into words the way a POSIX shell does,
+// without expanding variables.
+//
+// Words are separated by unquoted spaces, tabs and newlines. Text enclosed in
+// single quotes is literal. Text enclosed in double quotes is literal except
+// that a backslash escapes "$", "`", "\"", "\\" and newline. Outside of quotes
+// a backslash escapes any character. An escaped newline is removed. Quoted
+// empty text, such as "", is an empty word.
+//
+// Comments, operators, globs and command substitution are not interpreted.
+//
+// An unterminated quote or a trailing backslash is a *ShellError.
+func SplitShell(s string) ([]string, error) { return SplitShellExpand(s, nil) }
+
+// SplitShellExpand is like SplitShell but replaces "$NAME" and "${NAME}"
+// outside of single quotes with the result of mapping for NAME, like
+// [os.Expand]. NAME is an ASCII letter or underscore followed by ASCII
+// letters, digits and underscores. A "$" not followed by a name is literal.
+//
+// As in a shell, a result of an expansion outside of quotes is split into
+// words at white space while inside double quotes it is kept whole.
+//
+// If mapping is nil no expansion is performed.
+func SplitShellExpand(s string, mapping func(name string) string) ([]string, error) {
+	var sp = &shellSplitter{input: s, mapping: mapping, words: []string{}}
+	if err := sp.split(); err != nil {
+		return nil, err
+	}
+	return sp.words, nil
+}
+
+// QuoteShell returns args quoted so that a POSIX shell or [SplitShell]
+// splits the result back into args.
+//
+// Arguments that consist only of characters safe in a shell are not quoted,
+// others are enclosed in single quotes.
+func QuoteShell(args ...string) string {
+	var buf []byte
+	for i, arg := range args {
+		if i > 0 {
+			buf = append(buf, ' ')
+		}
+		buf = appendShellQuote(buf, arg)
+	}
+	return UnsafeString(buf)
+}
+
+// appendShellQuote appends arg quoted for a shell to dst.
+func appendShellQuote(dst []byte, arg string) []byte {
+	if arg == "" {
+		return append(dst, "''"...)
+	}
+	var safe = true
+	for i := 0; i < len(arg); i++ {
+		if !isShellSafe(arg[i]) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return append(dst, arg...)
+	}
+	dst = append(dst, '\'')
+	for i := 0; i < len(arg); i++ {
+		if arg[i] == '\'' {
+			dst = append(dst, `'\''`...)
+		} else {
+			dst = append(dst, arg[i])
+		}
+	}
+	return append(dst, '\'')
+}
+
+// isShellSafe returns true if c needs no quoting in a shell.
+func isShellSafe(c byte) bool {
+	return IsAlphanumeric(c) || strings.IndexByte("_@%+=:,./-", c) >= 0
+}
+
+// isShellSpace returns true if c separates shell words.
+func isShellSpace(c byte) bool { return c == ' ' || c == '\t' || c == '\n' }
+
+// shellSplitter splits a command line into words.
+type shellSplitter struct {
+	input   string
+	pos     int
+	mapping func(string) string
+	words   []string
+	// word is the word being built.
+	word []byte
+	// inWord is true if a word is being built, even if it is empty.
+	inWord bool
+}
+
+// error returns a *ShellError at pos.
+func (self *shellSplitter) error(pos int, message string) error {
+	return &ShellError{Input: self.input, Pos: pos, Message: message}
+}
+
+// add appends s to the current word.
+func (self *shellSplitter) add(s string) {
+	self.word = append(self.word, s...)
+	self.inWord = true
+}
+
+// addByte appends c to the current word.
+func (self *shellSplitter) addByte(c byte) {
+	self.word = append(self.word, c)
+	self.inWord = true
+}
+
+// end ends the current word, if any.
+func (self *shellSplitter) end() {
+	if self.inWord {
+		self.words = append(self.words, string(self.word))
+		self.word, self.inWord = self.word[:0], false
+	}
+}
+
+// split splits the input into words.
+func (self *shellSplitter) split() error {
+	for self.pos < len(self.input) {
+		var c = self.input[self.pos]
+		switch {
+		case isShellSpace(c):
+			self.end()
+			self.pos++
+		case c == '\\':
+			if self.pos+1 >= len(self.input) {
+				return self.error(self.pos, "trailing backslash")
+			}
+			if next := self.input[self.pos+1]; next != '\n' {
+				self.addByte(next)
+			}
+			self.pos += 2
+		case c == '\'':
+			var end = strings.IndexByte(self.input[self.pos+1:], '\'')
+			if end < 0 {
+				return self.error(self.pos, "unterminated single quote")
+			}
+			self.add(self.input[self.pos+1 : self.pos+1+end])
+			self.pos += end + 2
+		case c == '"':
+			if err := self.doubleQuoted(); err != nil {
+				return err
+			}
+		case c == '$':
+			if err := self.expand(false); err != nil {
+				return err
+			}
+		default:
+			self.addByte(c)
+			self.pos++
+		}
+	}
+	self.end()
+	return nil
+}
+
+// doubleQuoted parses text in double quotes at current position.
+func (self *shellSplitter) doubleQuoted() error {
+	var start = self.pos
+	self.inWord = true
+	for self.pos++; self.pos < len(self.input); {
+		switch c := self.input[self.pos]; c {
+		case '"':
+			self.pos++
+			return nil
+		case '\\':
+			if self.pos+1 >= len(self.input) {
+				return self.error(start, "unterminated double quote")
+			}
+			switch next := self.input[self.pos+1]; next {
+			case '$', '`', '"', '\\':
+				self.addByte(next)
+			case '\n':
+			default:
+				self.addByte(c)
+				self.addByte(next)
+			}
+			self.pos += 2
+		case '$':
+			if err := self.expand(true); err != nil {
+				return err
+			}
+		default:
+			self.addByte(c)
+			self.pos++
+		}
+	}
+	return self.error(start, "unterminated double quote")
+}
+
+// expand expands a variable at current position.
+func (self *shellSplitter) expand(quoted bool) error {
+	var start = self.pos
+	self.pos++
+	if self.mapping == nil {
+		self.addByte('$')
+		return nil
+	}
+	var name string
+	if self.pos < len(self.input) && self.input[self.pos] == '{' {
+		var end = strings.IndexByte(self.input[self.pos:], '}')
+		if end < 0 {
+			return self.error(start, "unterminated variable")
+		}
+		name = self.input[self.pos+1 : self.pos+end]
+		if shellNameLen(name) != len(name) || name == "" {
+			return self.error(start, "bad variable name")
+		}
+		self.pos += end + 1
+	} else {
+		var n = shellNameLen(self.input[self.pos:])
+		if n == 0 {
+			self.addByte('$')
+			return nil
+		}
+		name = self.input[self.pos : self.pos+n]
+		self.pos += n
+	}
+
+	var value = self.mapping(name)
+	if quoted {
+		self.add(value)
+		return nil
+	}
+	for i := 0; i < len(value); i++ {
+		if isShellSpace(value[i]) {
+			self.end()
+		} else {
+			self.addByte(value[i])
+		}
+	}
+	return nil
+}
+
+// shellNameLen returns the length of the shell variable name prefix of s.
+func shellNameLen(s string) (n int) {
+	for n < len(s) && (s[n] == '_' || IsLetter(s[n]) || n > 0 && IsDigit(s[n])) {
+		n++
+	}
+	return
+}
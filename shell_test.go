@@ -0,0 +1,138 @@
+package strutils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSplitShell(t *testing.T) {
+	type splitSample struct {
+		in  string
+		out []string
+	}
+	samples := []splitSample{
+		{"", []string{}},
+		{"  \t\n ", []string{}},
+		{"run", []string{"run"}},
+		{`run --name 'my app' "x y"`, []string{"run", "--name", "my app", "x y"}},
+		{"  a\t b\nc  ", []string{"a", "b", "c"}},
+		{`'' ""`, []string{"", ""}},
+		{`a''b "c"d'e'`, []string{"ab", "cde"}},
+		{`'a\b' "a\b"`, []string{`a\b`, `a\b`}},
+		{`"\$ \" \\ \x"`, []string{`$ " \ \x`}},
+		{`a\ b \'c\"`, []string{"a b", `'c"`}},
+		{"a\\\nb", []string{"ab"}},
+		{"\"a\\\nb\"", []string{"ab"}},
+		{`'it'\''s'`, []string{"it's"}},
+		{`$HOME "${HOME}"`, []string{"$HOME", "${HOME}"}},
+	}
+
+	for _, sample := range samples {
+		out, err := SplitShell(sample.in)
+		if err != nil {
+			t.Errorf("unexpected error splitting %q: %v", sample.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(out, sample.out) {
+			t.Errorf("got %q splitting %q, expected %q", out, sample.in, sample.out)
+		}
+	}
+}
+
+func TestSplitShellExpand(t *testing.T) {
+	var env = map[string]string{
+		"HOME":  "/home/user",
+		"FLAGS": " -a  -b ",
+		"EMPTY": "",
+		"_x1":   "y",
+	}
+	var mapping = func(name string) string { return env[name] }
+
+	type expandSample struct {
+		in  string
+		out []string
+	}
+	samples := []expandSample{
+		{"cd $HOME", []string{"cd", "/home/user"}},
+		{"cd ${HOME}/src", []string{"cd", "/home/user/src"}},
+		{"ls $FLAGS", []string{"ls", "-a", "-b"}},
+		{`ls "$FLAGS"`, []string{"ls", " -a  -b "}},
+		{"x$FLAGS", []string{"x", "-a", "-b"}},
+		{"a $EMPTY b", []string{"a", "b"}},
+		{`a "$EMPTY" b`, []string{"a", "", "b"}},
+		{"'$HOME' \\$HOME \"\\$HOME\"", []string{"$HOME", "$HOME", "$HOME"}},
+		{"$_x1$_x1 $1 $ $-", []string{"yy", "$1", "$", "$-"}},
+		{"$UNSET", []string{}},
+	}
+
+	for _, sample := range samples {
+		out, err := SplitShellExpand(sample.in, mapping)
+		if err != nil {
+			t.Errorf("unexpected error splitting %q: %v", sample.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(out, sample.out) {
+			t.Errorf("got %q splitting %q, expected %q", out, sample.in, sample.out)
+		}
+	}
+}
+
+func TestSplitShellErrors(t *testing.T) {
+	type errorSample struct {
+		in  string
+		pos int
+	}
+	samples := []errorSample{
+		{`a 'b`, 2},
+		{`a "b`, 2},
+		{`a "b\`, 2},
+		{`a\`, 1},
+		{`a ${HOME`, 2},
+		{`a ${}`, 2},
+		{`a ${1x}`, 2},
+	}
+
+	for _, sample := range samples {
+		var (
+			_, err = SplitShellExpand(sample.in, func(string) string { return "" })
+			serr   *ShellError
+		)
+		if !errors.As(err, &serr) {
+			t.Errorf("expected ShellError for %q, got %v", sample.in, err)
+			continue
+		}
+		if serr.Pos != sample.pos || serr.Input != sample.in {
+			t.Errorf("got position %d for %q, expected %d", serr.Pos, sample.in, sample.pos)
+		}
+	}
+}
+
+func TestQuoteShell(t *testing.T) {
+	type quoteSample struct {
+		args []string
+		out  string
+	}
+	samples := []quoteSample{
+		{nil, ""},
+		{[]string{""}, "''"},
+		{[]string{"run", "--name=x", "a/b.c"}, "run --name=x a/b.c"},
+		{[]string{"my app", "$HOME"}, "'my app' '$HOME'"},
+		{[]string{"it's"}, `'it'\''s'`},
+		{[]string{"a\nb", `"`}, "'a\nb' '\"'"},
+	}
+
+	for _, sample := range samples {
+		var out = QuoteShell(sample.args...)
+		if out != sample.out {
+			t.Errorf("got %q quoting %q, expected %q", out, sample.args, sample.out)
+		}
+		back, err := SplitShell(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sample.args) > 0 && !reflect.DeepEqual(back, sample.args) {
+			t.Errorf("got %q splitting %q, expected %q", back, out, sample.args)
+		}
+	}
+}
@@ -6,8 +6,10 @@
 package strutils
 
 import (
-	"sort"
+	"iter"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Compare returns 1 if a > b, -1 if a < b or 0 if a == b.
@@ -22,133 +24,404 @@ func Compare(a, b string) int {
 
 // CompareFold returns 1 if a > b, -1 if a < b or 0 if a == b.
 // Comparison is not case-sensitive.
+//
+// Runes equal under simple Unicode case folding are equal, others are
+// ordered by their canonical case folded form, the smallest rune they are
+// equal to under folding. It does not allocate.
 func CompareFold(a, b string) int {
-	return Compare(strings.ToLower(a), strings.ToLower(b))
+	for len(a) > 0 && len(b) > 0 {
+		var (
+			ra, na = utf8.DecodeRuneInString(a)
+			rb, nb = utf8.DecodeRuneInString(b)
+		)
+		if c := compareFoldRune(ra, rb); c != 0 {
+			return c
+		}
+		a, b = a[na:], b[nb:]
+	}
+	return Compare(a, b)
 }
 
 // Return s prefix up to sep starting from the left.
 // If sep not found returns an empty string.
 func FetchLeft(s, sep string) string {
-	var i = strings.Index(s, sep)
-	if i < 0 {
-		return ""
-	}
-	return s[0:i]
+	var prefix, _ = LookupLeft(s, sep)
+	return prefix
 }
 
 // Return s prefix up to sep starting from the left.
 // Separator search is case-insesitive, result casing is not modified.
 // If sep not found returns an empty string.
 func FetchLeftFold(s, sep string) string {
-	return s[0:strings.Index(strings.ToLower(s), strings.ToLower(sep))]
+	var prefix, _ = LookupLeftFold(s, sep)
+	return prefix
 }
 
 // Return s suffix up to sep starting from the right.
 // If sep not found returns an empty string.
 func FetchRight(s, sep string) string {
-	var i = -1
-	for j := strings.Index(s, sep); j > -1; j = strings.Index(s[j+1:], sep) {
-		i = j
-	}
-	return s[i+len(sep):]
+	var suffix, _ = LookupRight(s, sep)
+	return suffix
 }
 
 // Return s suffix up to sep starting from the right.
 // Separator search is case-insesitive, result casing is not modified.
 // If sep not found returns an empty string.
 func FetchRightFold(s, sep string) string {
-	return FetchRight(strings.ToLower(s), strings.ToLower(sep))
+	var suffix, _ = LookupRightFold(s, sep)
+	return suffix
+}
+
+// LookupLeft returns s prefix up to the first sep and true.
+// If sep not found returns an empty string and false.
+func LookupLeft(s, sep string) (prefix string, found bool) {
+	var i = strings.Index(s, sep)
+	if i < 0 {
+		return "", false
+	}
+	return s[:i], true
+}
+
+// LookupLeftFold returns s prefix up to the first sep and true.
+// Separator search is case-insesitive, result casing is not modified.
+// If sep not found returns an empty string and false.
+func LookupLeftFold(s, sep string) (prefix string, found bool) {
+	var i, _ = indexFold(s, sep)
+	if i < 0 {
+		return "", false
+	}
+	return s[:i], true
+}
+
+// LookupRight returns s suffix after the last sep and true.
+// If sep not found returns an empty string and false.
+func LookupRight(s, sep string) (suffix string, found bool) {
+	var i = strings.LastIndex(s, sep)
+	if i < 0 {
+		return "", false
+	}
+	return s[i+len(sep):], true
+}
+
+// LookupRightFold returns s suffix after the last sep and true.
+// Separator search is case-insesitive, result casing is not modified.
+// If sep not found returns an empty string and false.
+func LookupRightFold(s, sep string) (suffix string, found bool) {
+	var i, n = lastIndexFold(s, sep)
+	if i < 0 {
+		return "", false
+	}
+	return s[i+n:], true
 }
 
 // HasPrefixFold tests whether the string s begins with prefix.
 // Case-insensitive.
 func HasPrefixFold(s, prefix string) bool {
-	return strings.HasPrefix(strings.ToLower(s), strings.ToLower(prefix))
+	_, ok := prefixFold(s, prefix)
+	return ok
 }
 
 // HasSuffixFold tests whether the string s ends with suffix
 // Case-insensitive.
 func HasSuffixFold(s, suffix string) bool {
-	return strings.HasSuffix(strings.ToLower(s), strings.ToLower(suffix))
+	_, ok := suffixFold(s, suffix)
+	return ok
 }
 
 // IndexFold returns the index of the first instance of substr in s, or -1 if
 // substr is not present in s. Search is case-insensitive.
+//
+// Returned index is a byte offset in s. Length of the matched text in s may
+// differ from length of substr, for instance "ẞ" matches "ß".
 func IndexFold(s, substr string) int {
-	return strings.Index(strings.ToLower(s), strings.ToLower(substr))
+	var i, _ = indexFold(s, substr)
+	return i
 }
 
-// primeRK is the prime base used in Rabin-Karp algorithm.
-const primeRK = 16777619
+// equalFoldRune returns true if a and b are equal under simple Unicode case
+// folding.
+func equalFoldRune(a, b rune) bool {
+	if a == b {
+		return true
+	}
+	if a < utf8.RuneSelf && b < utf8.RuneSelf {
+		return IsLetter(byte(a)) && ToLower(byte(a)) == ToLower(byte(b))
+	}
+	for f := unicode.SimpleFold(a); f != a; f = unicode.SimpleFold(f) {
+		if f == b {
+			return true
+		}
+	}
+	return false
+}
 
-// hashstr returns the hash and the appropriate multiplicative
-// factor for use in Rabin-Karp algorithm.
-func hashstr(sep string) (uint32, uint32) {
-	hash := uint32(0)
-	for i := 0; i < len(sep); i++ {
-		hash = hash*primeRK + uint32(sep[i])
+// compareFoldRune compares runes a and b under simple Unicode case folding,
+// ordering runes that are not equal by their canonical case folded form.
+func compareFoldRune(a, b rune) int {
+	if a == b {
+		return 0
+	}
+	if a, b = foldRune(a), foldRune(b); a == b {
+		return 0
+	} else if a < b {
+		return -1
+	}
+	return 1
+}
 
+// foldRune returns the canonical case folded form of r, the smallest rune
+// equal to r under simple Unicode case folding.
+func foldRune(r rune) rune {
+	if r < utf8.RuneSelf {
+		return rune(ToUpper(byte(r)))
 	}
-	var pow, sq uint32 = 1, primeRK
-	for i := len(sep); i > 0; i >>= 1 {
-		if i&1 != 0 {
-			pow *= sq
+	var min = r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < min {
+			min = f
 		}
-		sq *= sq
 	}
-	return hash, pow
+	return min
 }
 
-// Indexes returns a slice of all indexes of "sep" starting byte positions in
-// "s", or an empty slice if none are present in "s".
-func Indexes(s, sep string) (r []int) {
-	n := len(sep)
-	switch {
-	case n == 0:
-		return
-	case n == 1:
-		c := sep[0]
-		// special case worth making fast
-		for i := 0; i < len(s); i++ {
-			if s[i] == c {
-				r = append(r, i)
-			}
+// prefixFold returns the length of the prefix of s equal to prefix under
+// simple Unicode case folding and true, or 0 and false if s does not begin
+// with prefix.
+func prefixFold(s, prefix string) (n int, ok bool) {
+	for _, pr := range prefix {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if size == 0 || !equalFoldRune(r, pr) {
+			return 0, false
+		}
+		n += size
+	}
+	return n, true
+}
+
+// suffixFold returns the length of the suffix of s equal to suffix under
+// simple Unicode case folding and true, or 0 and false if s does not end
+// with suffix.
+func suffixFold(s, suffix string) (n int, ok bool) {
+	for len(suffix) > 0 {
+		var (
+			r, size   = utf8.DecodeLastRuneInString(s[:len(s)-n])
+			sr, ssize = utf8.DecodeLastRuneInString(suffix)
+		)
+		if size == 0 || !equalFoldRune(r, sr) {
+			return 0, false
+		}
+		n, suffix = n+size, suffix[:len(suffix)-ssize]
+	}
+	return n, true
+}
+
+// indexFold returns the index and length of the first instance of substr in
+// s under simple Unicode case folding or -1 and 0 if substr is not present.
+func indexFold(s, substr string) (i, n int) {
+	if isASCII(substr) {
+		return indexFoldASCII(s, substr)
+	}
+	var ok bool
+	for i = 0; i <= len(s); {
+		if n, ok = prefixFold(s[i:], substr); ok {
+			return
+		}
+		if i == len(s) {
+			break
 		}
-		return
-	case n == len(s):
-		if sep == s {
-			r = append(r, 0)
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return -1, 0
+}
+
+// lastIndexFold returns the index and length of the last instance of substr
+// in s under simple Unicode case folding or -1 and 0 if substr is not
+// present.
+func lastIndexFold(s, substr string) (i, n int) {
+	if isASCII(substr) {
+		return lastIndexFoldASCII(s, substr)
+	}
+	var ok bool
+	for i = len(s); i >= 0; {
+		if n, ok = prefixFold(s[i:], substr); ok {
 			return
 		}
-	case n > len(s):
-		return
-	}
-	// Hash sep.
-	hashsep, pow := hashstr(sep)
-	var h uint32
-	for i := 0; i < n; i++ {
-		h = h*primeRK + uint32(s[i])
-	}
-	if h == hashsep && s[:n] == sep {
-		r = append(r, 0)
-		return
-	}
-	for i := n; i < len(s); {
-		h *= primeRK
-		h += uint32(s[i])
-		h -= pow * uint32(s[i-n])
-		i++
-		if h == hashsep && s[i-n:i] == sep {
-			r = append(r, i-n)
+		if i == 0 {
+			break
+		}
+		_, size := utf8.DecodeLastRuneInString(s[:i])
+		i -= size
+	}
+	return -1, 0
+}
+
+// indexFoldASCII is indexFold for an ASCII substr.
+//
+// Bytes are compared with ASCII case folding. Only the Kelvin sign and the
+// long s fold to ASCII letters, "k" and "s", so runes of s that are not ASCII
+// are compared with prefixFold only where they start a match of one.
+func indexFoldASCII(s, substr string) (i, n int) {
+	if len(substr) == 0 {
+		return 0, 0
+	}
+	var first = ToLower(substr[0])
+	for i = 0; i+len(substr) <= len(s); i++ {
+		var c = s[i]
+		if c >= utf8.RuneSelf {
+			if (first == 'k' || first == 's') && utf8.RuneStart(c) {
+				if n, ok := prefixFold(s[i:], substr); ok {
+					return i, n
+				}
+			}
+			continue
+		}
+		if ToLower(c) != first {
+			continue
+		}
+		if n, ok := prefixFoldASCII(s[i:], substr); ok {
+			return i, n
+		}
+	}
+	return -1, 0
+}
+
+// lastIndexFoldASCII is lastIndexFold for an ASCII substr, see
+// indexFoldASCII.
+func lastIndexFoldASCII(s, substr string) (i, n int) {
+	if len(substr) == 0 {
+		return len(s), 0
+	}
+	var first = ToLower(substr[0])
+	for i = len(s) - len(substr); i >= 0; i-- {
+		var c = s[i]
+		if c >= utf8.RuneSelf {
+			if (first == 'k' || first == 's') && utf8.RuneStart(c) {
+				if n, ok := prefixFold(s[i:], substr); ok {
+					return i, n
+				}
+			}
+			continue
+		}
+		if ToLower(c) != first {
+			continue
+		}
+		if n, ok := prefixFoldASCII(s[i:], substr); ok {
+			return i, n
 		}
 	}
-	return r
+	return -1, 0
 }
 
+// prefixFoldASCII is prefixFold for an ASCII prefix. It falls back to
+// prefixFold if s has a rune that is not ASCII where prefix is compared.
+func prefixFoldASCII(s, prefix string) (n int, ok bool) {
+	if len(s) < len(prefix) {
+		return 0, false
+	}
+	for i := 0; i < len(prefix); i++ {
+		var c = s[i]
+		if c >= utf8.RuneSelf {
+			return prefixFold(s, prefix)
+		}
+		if c != prefix[i] && ToLower(c) != ToLower(prefix[i]) {
+			return 0, false
+		}
+	}
+	return len(prefix), true
+}
+
+// isASCII returns true if s contains only ASCII bytes.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// IndexOptions define how [Indexes] searches for a separator.
+type IndexOptions struct {
+	// Overlapping, if true, reports matches that overlap previous matches,
+	// so that "aa" is found in "aaa" at 0 and 1. Otherwise the search
+	// resumes after the end of each match and "aa" is found at 0 only.
+	Overlapping bool
+
+	// Fold, if true, matches under simple Unicode case folding as
+	// [IndexFold]. Matches then start at rune boundaries only.
+	Fold bool
+
+	// Start is the byte offset in s at which the search starts. Offsets of
+	// matches are still relative to the start of s.
+	Start int
+
+	// Max, if greater than zero, is the maximum number of matches reported.
+	Max int
+}
+
+// DefaultIndexOptions are the options used by [Indexes] and [IndexesSeq].
+var DefaultIndexOptions = IndexOptions{Overlapping: true}
+
 // Indexes returns a slice of all indexes of "sep" starting byte positions in
-// "s", or an empty slice if none are present in "s". Case-insensitive.
+// "s", including those of overlapping matches, or an empty slice if none are
+// present in "s".
+func Indexes(s, sep string) []int { return DefaultIndexOptions.Indexes(s, sep) }
+
+// IndexesSeq returns an iterator over all indexes of "sep" starting byte
+// positions in "s", including those of overlapping matches.
+func IndexesSeq(s, sep string) iter.Seq[int] { return DefaultIndexOptions.IndexesSeq(s, sep) }
+
+// IndexesFold returns a slice of all indexes of "sep" starting byte positions
+// in "s", or an empty slice if none are present in "s". Case-insensitive.
+//
+// Returned indexes are byte offsets in s.
 func IndexesFold(s, sep string) []int {
-	return Indexes(strings.ToLower(s), strings.ToLower(sep))
+	return IndexOptions{Overlapping: true, Fold: true}.Indexes(s, sep)
+}
+
+// Indexes returns a slice of indexes of "sep" starting byte positions in "s"
+// as defined by self or nil if there are none. An empty sep is never found.
+func (self IndexOptions) Indexes(s, sep string) (r []int) {
+	for i := range self.IndexesSeq(s, sep) {
+		r = append(r, i)
+	}
+	return
+}
+
+// IndexesSeq returns an iterator over indexes of "sep" starting byte
+// positions in "s" as defined by self. An empty sep is never found.
+// Iteration does not allocate.
+func (self IndexOptions) IndexesSeq(s, sep string) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		if len(sep) == 0 || self.Start > len(s) {
+			return
+		}
+		var i, n, count = max(self.Start, 0), 0, 0
+		for i < len(s) && (self.Max <= 0 || count < self.Max) {
+			var at int
+			if self.Fold {
+				if at, n = indexFold(s[i:], sep); at < 0 {
+					return
+				}
+			} else if at, n = strings.Index(s[i:], sep), len(sep); at < 0 {
+				return
+			}
+			if i += at; !yield(i) {
+				return
+			}
+			count++
+			switch {
+			case !self.Overlapping:
+				i += n
+			case self.Fold:
+				_, size := utf8.DecodeRuneInString(s[i:])
+				i += size
+			default:
+				i++
+			}
+		}
+	}
 }
 
 // Unwrap unpacks the string by removing prefix and suffix.
@@ -168,13 +441,15 @@ func Unwrap(s, prefix, suffix string) (string, bool) {
 
 // UnwrapFold is the case-insensitive version of Unpack.
 func UnwrapFold(s, prefix, suffix string) (string, bool) {
-	if !HasPrefixFold(s, prefix) {
+	var p, ok = prefixFold(s, prefix)
+	if !ok {
 		return s, false
 	}
-	if !HasSuffixFold(s, suffix) {
+	var n int
+	if n, ok = suffixFold(s[p:], suffix); !ok {
 		return s, false
 	}
-	return s[len(prefix) : len(s)-len(suffix)], true
+	return s[p : len(s)-n], true
 }
 
 // UnquoteSingle removes single quotes around s and returns it and true on
@@ -197,6 +472,8 @@ func QuoteDouble(s string) string { return Wrap(s, "\"", "\"") }
 // Matches "text" against "pattern". Case insensitive. Returns truth.
 // * matches any number of characters.
 // ? matches one character.
+//
+// See [CompileWildcard] for extended syntax and options.
 func MatchesWildcard(text, pattern string) bool {
 	if text == "" || pattern == "" {
 		return false
@@ -210,7 +487,7 @@ func MatchesWildcard(text, pattern string) bool {
 		if w[iw] == '*' {
 			break
 		}
-		if w[iw] != '?' && !strings.EqualFold(string(t[it]), string(w[iw])) {
+		if w[iw] != '?' && !equalFoldRune(t[it], w[iw]) {
 			return false
 		}
 		it++
@@ -227,7 +504,7 @@ func MatchesWildcard(text, pattern string) bool {
 			sw = iw
 			st = it
 		} else {
-			if w[iw] == '?' || strings.EqualFold(string(t[it]), string(w[iw])) {
+			if w[iw] == '?' || equalFoldRune(t[it], w[iw]) {
 				it++
 				iw++
 			} else {
@@ -251,172 +528,210 @@ func MatchesWildcard(text, pattern string) bool {
 // If sep was not found returns s starting at start and -1.
 // If the end of s was reached resulting next will be -1.
 // Returns an empty string and -1 if start is out of range or sep is empty.
+//
+// See [Segments] for an iterator over segments.
 func Segment(s, sep string, start int) (segment string, next int) {
-	if len(s) == 0 || start < 0 || start > len(s)-1 {
+	if len(s) == 0 || len(sep) == 0 || start < 0 || start > len(s)-1 {
 		return "", -1
 	}
 	var end = strings.Index(s[start:], sep)
 	if end == -1 {
 		return s[start:], -1
 	}
-	if end == 0 {
-		start = start + len(sep)
-		end = start + end
-		if start+len(sep) > len(s) {
-			return s[start : start+len(sep)], -1
-		}
-		return s[start : start+len(sep)], start + len(sep)
-	}
 	end = start + end
 	return s[start:end], end + len(sep)
 }
 
 // SegmentFold is the case-insensitive version of Segment.
 func SegmentFold(s, sep string, start int) (segment string, next int) {
-	if len(s) == 0 || start < 0 || start > len(s)-1 {
+	if len(s) == 0 || len(sep) == 0 || start < 0 || start > len(s)-1 {
 		return "", -1
 	}
-	var end = IndexFold(s[start:], sep)
+	var end, n = indexFold(s[start:], sep)
 	if end == -1 {
 		return s[start:], -1
 	}
-	if end == 0 {
-		start = start + len(sep)
-		end = start + end
-		if start+len(sep) > len(s) {
-			return s[start : start+len(sep)], -1
-		}
-		return s[start : start+len(sep)], start + len(sep)
-	}
 	end = start + end
-	return s[start:end], end + len(sep)
+	return s[start:end], end + n
 }
 
-// WrapText wraps text into multiple lines at first whitespace before or exactly
-// at cols.
-//
-// If a word is longer than cols and force is true it is split at cols length
-// regardless of white space. If force is false, the word is not split and
-// placed into its own line at first next whitespace.
-//
-// Allocates runes of text and appends to out without preallocation.
-func WrapText(text string, cols int, force bool) (out []string) {
+// SegmentOptions define how [Segments] splits a string.
+type SegmentOptions struct {
+	// Quotes is a set of quote runes, for instance "\"'". A separator inside
+	// text enclosed in a pair of the same quote rune does not split it.
+	Quotes string
 
-	var (
-		idx   = 0            // scan index
-		start = 0            // copy offset
-		space = -1           // last space position
-		col   = 0            // column counter
-		runes = []rune(text) // alloc
-	)
+	// Brackets is a sequence of pairs of opening and closing bracket runes,
+	// for instance "()[]{}". A separator inside text enclosed in a pair of
+	// brackets does not split it. Brackets nest and a closing bracket that
+	// does not close the innermost open bracket is ordinary text.
+	Brackets string
 
-	for l := len(runes); idx < l; {
+	// Escape, if true, makes a backslash escape the rune that follows it so
+	// that an escaped separator, quote or bracket is ordinary text.
+	Escape bool
 
-		// Wrap at newlines.
-		if runes[idx] == '\n' {
-			out = append(out, text[start:idx])
-			col = 0
-			start = idx + 1
-			space = -1
-			idx++
-			continue
-		}
-
-		var isSpace = runes[idx] == ' '
-		if isSpace && col == 0 {
-			idx++
-			start = idx
-			continue
-		}
+	// Fold, if true, matches sep case-insensitively.
+	Fold bool
 
-		if col == cols-1 {
+	// Trim, if true, trims leading and trailing white space from segments.
+	Trim bool
 
-			// Last col is space.
-			if isSpace {
-				out = append(out, text[start:idx])
-				col = 0
-				start = idx + 1
-				space = -1
-				idx++
-				continue
-			}
+	// SkipEmpty, if true, skips empty segments, after trimming if Trim is
+	// true.
+	SkipEmpty bool
+}
 
-			// Wrap at last space.
-			if space > -1 {
-				out = append(out, text[start:space])
-				col = cols - (space - start) - 1
-				start = space + 1
-				space = -1
-				idx++
-				continue
+// Segments returns an iterator over segments of s separated by sep as
+// defined by options. Like [strings.Split] it yields an empty segment for
+// each pair of adjacent separators and for a separator at either end of s.
+//
+// Segments are yielded as they appear in s, including quotes, brackets and
+// escapes. An unterminated quote or bracket extends to the end of s. If sep
+// is empty s is yielded as a single segment. Separators are recognized
+// before quotes and brackets. Iteration does not allocate unless brackets
+// nest deeper than 8 levels.
+func Segments(s, sep string, options SegmentOptions) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		var (
+			start   = 0
+			quote   rune
+			buf     [8]rune
+			closers = buf[:0]
+			emit    = func(segment string) bool {
+				if options.Trim {
+					segment = strings.TrimSpace(segment)
+				}
+				return options.SkipEmpty && segment == "" || yield(segment)
 			}
-
-			// Wrap if forced or next rune is a space.
-			if force || idx < l-1 && runes[idx+1] == ' ' {
-				out = append(out, text[start:idx+1])
-				start = idx + 1
-				idx++
-				col = 0
-				space = -1
-				continue
+		)
+		for i := 0; i < len(s); {
+			if quote == 0 && len(closers) == 0 && len(sep) > 0 {
+				var n, ok = len(sep), strings.HasPrefix(s[i:], sep)
+				if options.Fold {
+					n, ok = prefixFold(s[i:], sep)
+				}
+				if ok {
+					if !emit(s[start:i]) {
+						return
+					}
+					i += n
+					start = i
+					continue
+				}
 			}
-
-		}
-
-		if isSpace {
-
-			if col > cols-1 {
-				// Word is longer than cols.
-				out = append(out, text[start:idx])
-				col = 0
-				start = idx + 1
-				space = -1
-				idx++
-				continue
+			var r, size = utf8.DecodeRuneInString(s[i:])
+			switch {
+			case options.Escape && r == '\\':
+				_, next := utf8.DecodeRuneInString(s[i+size:])
+				size += next
+			case quote != 0:
+				if r == quote {
+					quote = 0
+				}
+			case strings.ContainsRune(options.Quotes, r):
+				quote = r
+			case len(closers) > 0 && r == closers[len(closers)-1]:
+				closers = closers[:len(closers)-1]
+			default:
+				if closer, ok := bracketCloser(options.Brackets, r); ok {
+					closers = append(closers, closer)
+				}
 			}
-
-			space = idx
+			i += size
 		}
-
-		col++
-		idx++
+		emit(s[start:])
 	}
+}
 
-	if start < len(runes) {
-		out = append(out, text[start:])
+// bracketCloser returns the closing bracket of opening bracket r and true
+// or 0 and false if r is not an opening bracket in brackets.
+func bracketCloser(brackets string, r rune) (rune, bool) {
+	for len(brackets) > 0 {
+		var open, size = utf8.DecodeRuneInString(brackets)
+		var close, csize = utf8.DecodeRuneInString(brackets[size:])
+		if csize == 0 {
+			break
+		}
+		if open == r {
+			return close, true
+		}
+		brackets = brackets[size+csize:]
 	}
-
-	return
+	return 0, false
 }
 
 // Unique returns unique strings from in.
 //
 // Returned strings are in order as passed in.
 func Unique(in ...string) (out []string) {
+	var seen = make(map[string]struct{}, len(in))
+	out = make([]string, 0, len(in))
+	for _, s := range in {
+		if _, ok := seen[s]; !ok {
+			seen[s] = struct{}{}
+			out = append(out, s)
+		}
+	}
+	return
+}
 
+// UniqueFold returns strings from in that are unique under simple Unicode
+// case folding.
+//
+// Returned strings are in order as passed in, of strings equal under case
+// folding the first is returned.
+func UniqueFold(in ...string) (out []string) {
 	var (
-		m = make(map[string]int)
-		r = make(map[int]string)
+		seen = make(map[string]struct{}, len(in))
+		key  []byte
 	)
-
-	for i, s := range in {
-		if _, ok := m[s]; !ok {
-			m[s] = i
+	out = make([]string, 0, len(in))
+	for _, s := range in {
+		key = key[:0]
+		for _, r := range s {
+			key = utf8.AppendRune(key, foldRune(r))
+		}
+		if _, ok := seen[string(key)]; !ok {
+			seen[string(key)] = struct{}{}
+			out = append(out, s)
 		}
 	}
+	return
+}
 
-	var n = make([]int, 0, len(m))
-	for k, v := range m {
-		r[v] = k
-		n = append(n, v)
+// UniqueInPlace moves unique strings of in to its start, in order as passed
+// in, and returns in shortened to them. Elements of in past the returned
+// slice are zeroed.
+func UniqueInPlace(in []string) []string {
+	var (
+		seen = make(map[string]struct{}, len(in))
+		n    = 0
+	)
+	for _, s := range in {
+		if _, ok := seen[s]; !ok {
+			seen[s] = struct{}{}
+			in[n] = s
+			n++
+		}
 	}
-	sort.Ints(n)
-
-	out = make([]string, 0, len(m))
+	clear(in[n:])
+	return in[:n]
+}
 
-	for _, i := range n {
-		out = append(out, r[i])
+// UniqueFunc returns elements of in whose keys returned by key are unique.
+//
+// Returned elements are in order as passed in, of elements with equal keys
+// the first is returned.
+func UniqueFunc[T any, K comparable](in []T, key func(T) K) (out []T) {
+	var seen = make(map[K]struct{}, len(in))
+	out = make([]T, 0, len(in))
+	for _, v := range in {
+		var k = key(v)
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			out = append(out, v)
+		}
 	}
-
 	return
 }
@@ -6,7 +6,11 @@ package strutils
 
 import (
 	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestStringFunctions(t *testing.T) {
@@ -74,9 +78,18 @@ func TestStringFunctions(t *testing.T) {
 	if segment, next := Segment("sinferopopokatepetl", "te", 11); segment != "ka" || next != 15 {
 		t.Fatal("Segment failed")
 	}
-	if segment, next := Segment("sinferopopokatepetl", "pe", 15); segment != "tl" || next != 19 {
+	if segment, next := Segment("sinferopopokatepetl", "pe", 15); segment != "" || next != 17 {
 		t.Fatal("Segment failed")
 	}
+	if segment, next := Segment("sinferopopokatepetl", "pe", 17); segment != "tl" || next != -1 {
+		t.Fatal("Segment failed")
+	}
+	if segment, next := Segment("sinferopopokatepetl", "", 0); segment != "" || next != -1 {
+		t.Fatal("Segment failed")
+	}
+	if segment, next := SegmentFold("a--b", "-", 2); segment != "" || next != 3 {
+		t.Fatal("SegmentFold failed")
+	}
 
 	if segment, next := SegmentFold("sinferopopokatepetl", "pOpO", 0); segment != "sinfero" || next != 11 {
 		t.Fatal("Segment failed")
@@ -97,30 +110,6 @@ func TestUnpack(t *testing.T) {
 	}
 }
 
-const loremIpsum = `Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.
-
-Sed ut perspiciatis unde omnis iste natus error sit voluptatem accusantium doloremque laudantium, totam rem aperiam, eaque ipsa quae ab illo inventore veritatis et quasi architecto beatae vitae dicta sunt explicabo. Nemo enim ipsam voluptatem quia voluptas sit aspernatur aut odit aut fugit, sed quia consequuntur magni dolores eos qui ratione voluptatem sequi nesciunt. Neque porro quisquam est, qui dolorem ipsum quia dolor sit amet, consectetur, adipisci velit, sed quia non numquam eius modi tempora incidunt ut labore et dolore magnam aliquam quaerat voluptatem. Ut enim ad minima veniam, quis nostrum exercitationem ullam corporis suscipit laboriosam, nisi ut aliquid ex ea commodi consequatur? Quis autem vel eum iure reprehenderit qui in ea voluptate velit esse quam nihil molestiae consequatur, vel illum qui dolorem eum fugiat quo voluptas nulla pariatur?
-
-At vero eos et accusamus et iusto odio dignissimos ducimus qui blanditiis praesentium voluptatum deleniti atque corrupti quos dolores et quas molestias excepturi sint occaecati cupiditate non provident, similique sunt in culpa qui officia deserunt mollitia animi, id est laborum et dolorum fuga. Et harum quidem rerum facilis est et expedita distinctio. Nam libero tempore, cum soluta nobis est eligendi optio cumque nihil impedit quo minus id quod maxime placeat facere possimus, omnis voluptas assumenda est, omnis dolor repellendus. Temporibus autem quibusdam et aut officiis debitis aut rerum necessitatibus saepe eveniet ut et voluptates repudiandae sint et molestiae non recusandae. Itaque earum rerum hic tenetur a sapiente delectus, ut aut reiciendis voluptatibus maiores alias consequatur aut perferendis doloribus asperiores repellat.`
-
-func TestWrapText(t *testing.T) {
-	if testing.Verbose() {
-		for _, line := range WrapText(loremIpsum, 100, false) {
-			if testing.Verbose() {
-				fmt.Println(line)
-			}
-		}
-		return
-	}
-	WrapText(loremIpsum, 100, false)
-}
-
-func BenchmarkWrapText(b *testing.B) {
-	for i := 0; i < b.N; i++ {
-		WrapText(loremIpsum, 80, false)
-	}
-}
-
 func BenchmarkMatchesWildCard(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		MatchesWildcard("Sinferopopokatepetl", "Si?fero*ka?epe?l")
@@ -169,4 +158,378 @@ func BenchmarkUnique(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		Unique(input...)	
 	}
-}
\ No newline at end of file
+}
+
+func TestUniqueVariants(t *testing.T) {
+	var input = []string{"one", "two", "One", "three", "two", "ONE", "Straße", "STRASSE", "straẞe"}
+
+	if out := Unique(input...); !reflect.DeepEqual(out, []string{"one", "two", "One", "three", "ONE", "Straße", "STRASSE", "straẞe"}) {
+		t.Errorf("Unique failed: %q", out)
+	}
+	if out := Unique(); out == nil || len(out) != 0 {
+		t.Errorf("Unique failed on empty input: %#v", out)
+	}
+	if out := UniqueFold(input...); !reflect.DeepEqual(out, []string{"one", "two", "three", "Straße", "STRASSE"}) {
+		t.Errorf("UniqueFold failed: %q", out)
+	}
+
+	var in = []string{"a", "b", "a", "c", "b"}
+	if out := UniqueInPlace(in); !reflect.DeepEqual(out, []string{"a", "b", "c"}) || &out[0] != &in[0] {
+		t.Errorf("UniqueInPlace failed: %q", out)
+	}
+	if !reflect.DeepEqual(in, []string{"a", "b", "c", "", ""}) {
+		t.Errorf("UniqueInPlace did not clear tail: %q", in)
+	}
+	if out := UniqueInPlace(nil); len(out) != 0 {
+		t.Errorf("UniqueInPlace failed on nil: %q", out)
+	}
+
+	type item struct {
+		id   int
+		name string
+	}
+	var items = []item{{1, "a"}, {2, "b"}, {1, "c"}, {3, "d"}, {2, "e"}}
+	if out := UniqueFunc(items, func(i item) int { return i.id }); !reflect.DeepEqual(out, []item{{1, "a"}, {2, "b"}, {3, "d"}}) {
+		t.Errorf("UniqueFunc failed: %v", out)
+	}
+}
+
+func BenchmarkUniqueFold(b *testing.B) {
+	var input = []string{"one", "two", "Three", "TWO", "four", "Four", "five"}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		UniqueFold(input...)
+	}
+}
+
+func TestFoldFunctions(t *testing.T) {
+	type compareSample struct {
+		a, b string
+		out  int
+	}
+	for _, sample := range []compareSample{
+		{"", "", 0},
+		{"abc", "ABC", 0},
+		{"abc", "ABD", -1},
+		{"abd", "ABC", 1},
+		{"ab", "ABC", -1},
+		{"straße", "STRAẞE", 0},
+		{"\u212Aelvin", "kelvin", 0},
+		{"a_", "A[", 1},
+		{"čćž", "ČĆŽ", 0},
+		{"ſ", "s", 0},
+		{"s", "t", -1},
+		{"ſ", "t", -1},
+		{"t", "ſ", 1},
+	} {
+		if out := CompareFold(sample.a, sample.b); out != sample.out {
+			t.Errorf("CompareFold(%q, %q) = %d, expected %d", sample.a, sample.b, out, sample.out)
+		}
+	}
+
+	type indexSample struct {
+		s, substr string
+		out       int
+	}
+	for _, sample := range []indexSample{
+		{"teststring", "STR", 4},
+		{"teststring", "xyz", -1},
+		{"teststring", "", 0},
+		{"İİ-abc", "ABC", 5},
+		{"ẞẞ-ss", "-SS", 6},
+		{"Grüße, ẞig", "SSIG", -1},
+		{"Grüße, ẞig", "ßIG", 9},
+	} {
+		if out := IndexFold(sample.s, sample.substr); out != sample.out {
+			t.Errorf("IndexFold(%q, %q) = %d, expected %d", sample.s, sample.substr, out, sample.out)
+		}
+	}
+
+	if out := IndexesFold("İaİAİ", "a"); !reflect.DeepEqual(out, []int{2, 5}) {
+		t.Errorf("IndexesFold failed: %v", out)
+	}
+	if out := IndexesFold("ẞxßX", "ßx"); !reflect.DeepEqual(out, []int{0, 4}) {
+		t.Errorf("IndexesFold failed: %v", out)
+	}
+	if out := IndexesFold("aaa", "AA"); !reflect.DeepEqual(out, []int{0, 1}) {
+		t.Errorf("IndexesFold failed: %v", out)
+	}
+
+	if !HasPrefixFold("ẞtraße", "ßTRA") || HasPrefixFold("ẞ", "ßß") {
+		t.Error("HasPrefixFold failed")
+	}
+	if HasSuffixFold("Straẞe", "SSE") || !HasSuffixFold("Straẞe", "ßE") {
+		t.Error("HasSuffixFold failed")
+	}
+
+	if out := FetchLeftFold("İstanbul-ẞtraße", "-ß"); out != "İstanbul" {
+		t.Errorf("FetchLeftFold failed: %q", out)
+	}
+	if out := FetchRightFold("İstanbul-ẞtraße-Ende", "-E"); out != "nde" {
+		t.Errorf("FetchRightFold failed: %q", out)
+	}
+	if out := FetchRightFold("TestString", "TS"); out != "tring" {
+		t.Errorf("FetchRightFold failed: %q", out)
+	}
+
+	if out, ok := UnwrapFold("ẞ-word-ẞ", "ß-", "-ß"); !ok || out != "word" {
+		t.Errorf("UnwrapFold failed: %q", out)
+	}
+	if out, ok := UnwrapFold("ẞ", "ß", "ß"); ok || out != "ẞ" {
+		t.Errorf("UnwrapFold failed: %q", out)
+	}
+
+	if segment, next := SegmentFold("İxẞyz", "ßY", 0); segment != "İx" || next != 7 {
+		t.Errorf("SegmentFold failed: %q, %d", segment, next)
+	}
+}
+
+// TestIndexFoldASCII checks that searches for ASCII substrings agree with a
+// rune by rune search, including runes that fold to ASCII letters.
+func TestIndexFoldASCII(t *testing.T) {
+	var (
+		rnd    = rand.New(rand.NewSource(1))
+		random = func(set string, max int) string {
+			var r = []rune(set)
+			var b = make([]rune, rnd.Intn(max+1))
+			for i := range b {
+				b[i] = r[rnd.Intn(len(r))]
+			}
+			return string(b)
+		}
+		naive = func(s, substr string, last bool) int {
+			var found = -1
+			for i := 0; i <= len(s); i++ {
+				if i < len(s) && !utf8.RuneStart(s[i]) {
+					continue
+				}
+				if _, ok := prefixFold(s[i:], substr); ok {
+					if !last {
+						return i
+					}
+					found = i
+				}
+			}
+			return found
+		}
+	)
+	for i := 0; i < 20000; i++ {
+		var s, substr = random("kKsSaA-\u212a\u017fßİ", 12), random("kKsSaA-", 3)
+		if got, want := IndexFold(s, substr), naive(s, substr, false); got != want {
+			t.Fatalf("IndexFold(%q, %q) = %d, expected %d", s, substr, got, want)
+		}
+		if got, _ := lastIndexFold(s, substr); got != naive(s, substr, true) {
+			t.Fatalf("lastIndexFold(%q, %q) = %d, expected %d", s, substr, got, naive(s, substr, true))
+		}
+	}
+}
+
+// foldHaystack is a large text to search with fold functions.
+var foldHaystack = strings.Repeat("The quick brown fox jumps over the lazy dog. ", 200) + "Needle in a HAYSTACK."
+
+func BenchmarkIndexFold(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		IndexFold(foldHaystack, "haystack")
+	}
+}
+
+// BenchmarkIndexFoldLower is the former implementation of IndexFold.
+func BenchmarkIndexFoldLower(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		strings.Index(strings.ToLower(foldHaystack), strings.ToLower("haystack"))
+	}
+}
+
+func BenchmarkIndexFoldUnicode(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		IndexFold(foldHaystack, "haystacß")
+	}
+}
+
+func BenchmarkLastIndexFold(b *testing.B) {
+	var s = "NEEDLE: " + foldHaystack
+	for i := 0; i < b.N; i++ {
+		lastIndexFold(s, "needle:")
+	}
+}
+
+func TestFoldFunctionsAllocs(t *testing.T) {
+	var s = "İstanbul-ẞtraße-Ende"
+	if n := testing.AllocsPerRun(100, func() {
+		CompareFold(s, "istanbul")
+		HasPrefixFold(s, "İSTAN")
+		HasSuffixFold(s, "ENDE")
+		IndexFold(s, "SSE")
+		FetchLeftFold(s, "-")
+		FetchRightFold(s, "-")
+		UnwrapFold(s, "i", "e")
+	}); n != 0 {
+		t.Errorf("got %v allocs, expected 0", n)
+	}
+}
+
+func TestFetchFunctions(t *testing.T) {
+	type fetchFuncs struct {
+		lookup func(s, sep string) (string, bool)
+		fetch  func(s, sep string) string
+	}
+	var funcs = map[string]fetchFuncs{
+		"Left":      {LookupLeft, FetchLeft},
+		"Right":     {LookupRight, FetchRight},
+		"LeftFold":  {LookupLeftFold, FetchLeftFold},
+		"RightFold": {LookupRightFold, FetchRightFold},
+	}
+
+	type fetchSample struct {
+		name   string
+		s, sep string
+		out    string
+		found  bool
+	}
+	samples := []fetchSample{
+		{"Left", "a.b.c", ".", "a", true},
+		{"Left", "a.b.c", "x", "", false},
+		{"Left", ".b.c", ".", "", true},
+		{"Left", "", ".", "", false},
+		{"Right", "a.b.c", ".", "c", true},
+		{"Right", "a..b..c", "..", "c", true},
+		{"Right", "a.b.c", "x", "", false},
+		{"Right", "a.b.", ".", "", true},
+		{"Right", "", ".", "", false},
+		{"LeftFold", "Left-SEP-Right", "sep", "Left-", true},
+		{"LeftFold", "Left-SEP-Right", "x", "", false},
+		{"LeftFold", "SEP-Right", "sep", "", true},
+		{"RightFold", "Left-SEP-Right-Sep-End", "sep", "-End", true},
+		{"RightFold", "Left-SEP-Right", "x", "", false},
+		{"RightFold", "Left-SEP", "sep", "", true},
+	}
+
+	for _, sample := range samples {
+		var f = funcs[sample.name]
+		out, found := f.lookup(sample.s, sample.sep)
+		if out != sample.out || found != sample.found {
+			t.Errorf("Lookup%s(%q, %q) = %q, %t, expected %q, %t",
+				sample.name, sample.s, sample.sep, out, found, sample.out, sample.found)
+		}
+		if out = f.fetch(sample.s, sample.sep); out != sample.out {
+			t.Errorf("Fetch%s(%q, %q) = %q, expected %q",
+				sample.name, sample.s, sample.sep, out, sample.out)
+		}
+	}
+}
+
+func TestIndexes(t *testing.T) {
+	type indexesSample struct {
+		options IndexOptions
+		s, sep  string
+		out     []int
+	}
+	var overlapping = IndexOptions{Overlapping: true}
+	samples := []indexesSample{
+		{overlapping, "", "a", nil},
+		{overlapping, "abc", "", nil},
+		{overlapping, "abc", "abcd", nil},
+		{overlapping, "abc", "abc", []int{0}},
+		{overlapping, "abxab", "ab", []int{0, 3}},
+		{overlapping, "aaaa", "aa", []int{0, 1, 2}},
+		{overlapping, "a.b.c.d.e", ".", []int{1, 3, 5, 7}},
+		{IndexOptions{}, "aaaa", "aa", []int{0, 2}},
+		{IndexOptions{}, "aaaaa", "aa", []int{0, 2}},
+		{IndexOptions{}, "a.b.c", ".", []int{1, 3}},
+		{IndexOptions{Start: 2}, "abxab", "ab", []int{3}},
+		{IndexOptions{Start: 3}, "abxab", "ab", []int{3}},
+		{IndexOptions{Start: 4}, "abxab", "ab", nil},
+		{IndexOptions{Start: 9}, "abxab", "ab", nil},
+		{IndexOptions{Start: -1}, "abxab", "ab", []int{0, 3}},
+		{IndexOptions{Max: 2}, "a.b.c.d", ".", []int{1, 3}},
+		{IndexOptions{Overlapping: true, Max: 1}, "aaaa", "aa", []int{0}},
+		{IndexOptions{Fold: true}, "ẞẞxßß", "ß", []int{0, 3, 7, 9}},
+		{IndexOptions{Fold: true}, "AAAA", "aa", []int{0, 2}},
+		{IndexOptions{Fold: true, Overlapping: true}, "AAAA", "aa", []int{0, 1, 2}},
+		{IndexOptions{Fold: true, Overlapping: true}, "ẞẞẞ", "ßß", []int{0, 3}},
+		{IndexOptions{Fold: true, Start: 1, Max: 1}, "aAaA", "a", []int{1}},
+	}
+
+	for _, sample := range samples {
+		if out := sample.options.Indexes(sample.s, sample.sep); !reflect.DeepEqual(out, sample.out) {
+			t.Errorf("got %v searching %q for %q %+v, expected %v", out, sample.s, sample.sep, sample.options, sample.out)
+		}
+		var seq []int
+		for i := range sample.options.IndexesSeq(sample.s, sample.sep) {
+			seq = append(seq, i)
+		}
+		if !reflect.DeepEqual(seq, sample.out) {
+			t.Errorf("IndexesSeq got %v searching %q for %q %+v, expected %v", seq, sample.s, sample.sep, sample.options, sample.out)
+		}
+	}
+
+	if out := Indexes("abab", "ab"); !reflect.DeepEqual(out, []int{0, 2}) {
+		t.Errorf("Indexes failed: %v", out)
+	}
+
+	var options = IndexOptions{Fold: true}
+	if n := testing.AllocsPerRun(100, func() {
+		for range IndexesSeq("a.b.c.d", ".") {
+		}
+		for range options.IndexesSeq("ẞ.ß.ẞ", "ß") {
+		}
+	}); n != 0 {
+		t.Errorf("got %v allocs, expected 0", n)
+	}
+}
+
+func TestSegments(t *testing.T) {
+	type segmentsSample struct {
+		options SegmentOptions
+		s, sep  string
+		out     []string
+	}
+	samples := []segmentsSample{
+		{SegmentOptions{}, "", ",", []string{""}},
+		{SegmentOptions{}, "a,b,c", ",", []string{"a", "b", "c"}},
+		{SegmentOptions{}, ",a,,b,", ",", []string{"", "a", "", "b", ""}},
+		{SegmentOptions{}, "a::b", "::", []string{"a", "b"}},
+		{SegmentOptions{}, "a,b", "", []string{"a,b"}},
+		{SegmentOptions{}, `a,"b,c",d`, ",", []string{"a", `"b`, `c"`, "d"}},
+		{SegmentOptions{Quotes: `"'`}, `a,"b,c",'d,"e',f`, ",", []string{"a", `"b,c"`, `'d,"e'`, "f"}},
+		{SegmentOptions{Quotes: `"`}, `a,"b,c`, ",", []string{"a", `"b,c`}},
+		{SegmentOptions{Brackets: "()[]"}, "f(a,g(b,c)),[d,e],x", ",", []string{"f(a,g(b,c))", "[d,e]", "x"}},
+		{SegmentOptions{Brackets: "()[]"}, "(a,]b),c", ",", []string{"(a,]b)", "c"}},
+		{SegmentOptions{Brackets: "()", Quotes: `"`}, `(")",a),b`, ",", []string{`(")",a)`, "b"}},
+		{SegmentOptions{Escape: true}, `a\,b,c\\,d`, ",", []string{`a\,b`, `c\\`, "d"}},
+		{SegmentOptions{Escape: true, Quotes: `"`}, `"a\",b",c`, ",", []string{`"a\",b"`, "c"}},
+		{SegmentOptions{Trim: true}, " a , b ,, ", ",", []string{"a", "b", "", ""}},
+		{SegmentOptions{Trim: true, SkipEmpty: true}, " a , b ,, ", ",", []string{"a", "b"}},
+		{SegmentOptions{SkipEmpty: true}, "", ",", nil},
+		{SegmentOptions{Fold: true}, "aANDbandc", "and", []string{"a", "b", "c"}},
+		{SegmentOptions{Fold: true}, "xẞyßz", "ß", []string{"x", "y", "z"}},
+	}
+
+	for _, sample := range samples {
+		var out []string
+		for segment := range Segments(sample.s, sample.sep, sample.options) {
+			out = append(out, segment)
+		}
+		if !reflect.DeepEqual(out, sample.out) {
+			t.Errorf("got %q splitting %q by %q %+v, expected %q", out, sample.s, sample.sep, sample.options, sample.out)
+		}
+	}
+
+	var n = 0
+	for range Segments("a,b,c", ",", SegmentOptions{}) {
+		if n++; n == 2 {
+			break
+		}
+	}
+	if n != 2 {
+		t.Error("Segments did not stop")
+	}
+
+	var options = SegmentOptions{Quotes: `"`, Brackets: "()", Escape: true, Trim: true}
+	if n := testing.AllocsPerRun(100, func() {
+		for range Segments(`a, "b,c", (d,e), f\,g`, ",", options) {
+		}
+	}); n != 0 {
+		t.Errorf("got %v allocs, expected 0", n)
+	}
+}
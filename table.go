@@ -0,0 +1,265 @@
+// Copyright 2026 Vedran Vuk. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package strutils
+
+import "strings"
+
+// Border specifies the style of table borders.
+type Border int
+
+const (
+	// BorderNone draws no borders, columns are separated by two spaces.
+	BorderNone Border = iota
+	// BorderASCII draws borders with "+", "-" and "|".
+	BorderASCII
+	// BorderUnicode draws borders with Unicode box drawing characters.
+	BorderUnicode
+)
+
+// String implements fmt.Stringer on Border.
+func (self Border) String() string {
+	switch self {
+	case BorderNone:
+		return "None"
+	case BorderASCII:
+		return "ASCII"
+	case BorderUnicode:
+		return "Unicode"
+	}
+	return "Invalid"
+}
+
+// borderChars are the characters of a border style.
+type borderChars struct {
+	// h and v are horizontal and vertical lines.
+	h, v string
+	// top, mid and bottom are left, cross and right joints of the top rule,
+	// the rule below the header and the bottom rule.
+	top, mid, bottom [3]string
+}
+
+// borders are the characters of bordered styles indexed by Border.
+var borders = [...]borderChars{
+	BorderASCII: {
+		"-", "|",
+		[3]string{"+", "+", "+"},
+		[3]string{"+", "+", "+"},
+		[3]string{"+", "+", "+"},
+	},
+	BorderUnicode: {
+		"─", "│",
+		[3]string{"┌", "┬", "┐"},
+		[3]string{"├", "┼", "┤"},
+		[3]string{"└", "┴", "┘"},
+	},
+}
+
+// TableFormatter formats rows of cells into a text table.
+//
+// Column widths are the display widths of their widest cells, see
+// [DisplayWidth]. If the table is wider than MaxWidth columns are narrowed,
+// widest first, and cells wider than their column are wrapped with
+// [WrapText] rules or truncated with an ellipsis. Newlines in cells start
+// new lines within the cell.
+//
+// Rows may have different numbers of cells, missing cells are empty.
+type TableFormatter struct {
+	// Header, if true, separates the first row from other rows with a rule.
+	// With [BorderNone] the rule is a line of dashes under each column.
+	Header bool
+
+	// Align is the alignment of cells in each column. Columns past the end
+	// of Align are aligned left. AlignJustify justifies all lines of a cell
+	// except the last.
+	Align []Alignment
+
+	// Border is the style of borders around and between cells.
+	Border Border
+
+	// MaxWidth is the maximum width of the table in columns, including
+	// borders. If zero or less width is not limited.
+	MaxWidth int
+
+	// Truncate, if true, truncates cells wider than their column with an
+	// ellipsis instead of wrapping them, see [Truncate] and
+	// [DefaultTruncateOptions].
+	Truncate bool
+}
+
+// Format returns rows formatted into a table of lines joined by newlines.
+func (self TableFormatter) Format(rows [][]string) string {
+	return strings.Join(self.Lines(rows), "\n")
+}
+
+// Lines returns rows formatted into lines of a table.
+func (self TableFormatter) Lines(rows [][]string) (out []string) {
+	var cols = 0
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+	if cols == 0 {
+		return nil
+	}
+	var widths = make([]int, cols)
+	for _, row := range rows {
+		for i, cell := range row {
+			for _, line := range strings.Split(cell, "\n") {
+				widths[i] = max(widths[i], DisplayWidth(strings.TrimSuffix(line, "\r")))
+			}
+		}
+	}
+	if self.MaxWidth > 0 {
+		var overhead = 2 * (cols - 1)
+		if self.Border != BorderNone {
+			overhead = 3*cols + 1
+		}
+		fitWidths(widths, self.MaxWidth-overhead)
+	}
+
+	var cells = make([][]string, cols)
+	if self.Border != BorderNone {
+		out = append(out, self.rule(widths, borders[self.Border].top))
+	}
+	for r, row := range rows {
+		var height = 1
+		for i := range cells {
+			var cell string
+			if i < len(row) {
+				cell = row[i]
+			}
+			cells[i] = self.cell(cell, widths[i])
+			height = max(height, len(cells[i]))
+		}
+		for l := 0; l < height; l++ {
+			out = append(out, self.line(cells, widths, l))
+		}
+		if r == 0 && self.Header && len(rows) > 1 {
+			out = append(out, self.rule(widths, borders[self.Border].mid))
+		}
+	}
+	if self.Border != BorderNone {
+		out = append(out, self.rule(widths, borders[self.Border].bottom))
+	}
+	return
+}
+
+// cell returns lines of cell fitted into width.
+func (self TableFormatter) cell(cell string, width int) (lines []string) {
+	if !self.Truncate {
+		return WrapOptions{Force: true}.Wrap(cell, width)
+	}
+	for _, line := range strings.Split(cell, "\n") {
+		lines = append(lines, Truncate(strings.TrimSpace(line), width, DefaultTruncateOptions))
+	}
+	return
+}
+
+// line returns the l-th line of a table row of cells.
+func (self TableFormatter) line(cells [][]string, widths []int, l int) string {
+	var (
+		b   = self.Border != BorderNone
+		buf []byte
+	)
+	for i, lines := range cells {
+		switch {
+		case b:
+			buf = append(buf, borders[self.Border].v...)
+			buf = append(buf, ' ')
+		case i > 0:
+			buf = append(buf, "  "...)
+		}
+		var text string
+		if l < len(lines) {
+			text = lines[l]
+		}
+		var align = AlignLeft
+		if i < len(self.Align) {
+			align = self.Align[i]
+		}
+		text = alignLine(text, widths[i], align, l >= len(lines)-1)
+		buf = append(buf, text...)
+		for n := widths[i] - DisplayWidth(text); n > 0; n-- {
+			buf = append(buf, ' ')
+		}
+		if b {
+			buf = append(buf, ' ')
+		}
+	}
+	if !b {
+		return strings.TrimRight(UnsafeString(buf), " ")
+	}
+	buf = append(buf, borders[self.Border].v...)
+	return UnsafeString(buf)
+}
+
+// rule returns a horizontal rule for columns of widths with joints. Without
+// borders it returns dashes under columns separated by two spaces.
+func (self TableFormatter) rule(widths []int, joints [3]string) string {
+	var buf []byte
+	if self.Border == BorderNone {
+		for i, width := range widths {
+			if i > 0 {
+				buf = append(buf, "  "...)
+			}
+			for n := width; n > 0; n-- {
+				buf = append(buf, '-')
+			}
+		}
+		return UnsafeString(buf)
+	}
+	for i, width := range widths {
+		if i == 0 {
+			buf = append(buf, joints[0]...)
+		} else {
+			buf = append(buf, joints[1]...)
+		}
+		for n := width + 2; n > 0; n-- {
+			buf = append(buf, borders[self.Border].h...)
+		}
+	}
+	buf = append(buf, joints[2]...)
+	return UnsafeString(buf)
+}
+
+// fitWidths narrows widths so that their sum is at most total, widest
+// first. Widths are kept at least 1 wide.
+func fitWidths(widths []int, total int) {
+	var sum = 0
+	for _, width := range widths {
+		sum += width
+	}
+	if sum <= total {
+		return
+	}
+	// Columns narrower than an equal share of what remains keep their
+	// width, others share the rest.
+	var (
+		fixed = make([]bool, len(widths))
+		n     = len(widths)
+	)
+	for changed := true; changed && n > 0; {
+		changed = false
+		var share = total / n
+		for i, width := range widths {
+			if !fixed[i] && width <= share {
+				fixed[i], changed = true, true
+				total -= width
+				n--
+			}
+		}
+	}
+	var i = 0
+	for j := range widths {
+		if fixed[j] {
+			continue
+		}
+		var width = total / n
+		if i < total%n {
+			width++
+		}
+		widths[j] = max(width, 1)
+		i++
+	}
+}
@@ -0,0 +1,88 @@
+package strutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTableFormatter(t *testing.T) {
+	type tableSample struct {
+		formatter TableFormatter
+		rows      [][]string
+		out       []string
+	}
+	var rows = [][]string{
+		{"Name", "Qty", "Note"},
+		{"apple", "3", "red"},
+		{"日本", "12"},
+	}
+	samples := []tableSample{
+		{TableFormatter{}, nil, nil},
+		{TableFormatter{}, rows, []string{
+			"Name   Qty  Note",
+			"apple  3    red",
+			"日本   12",
+		}},
+		{TableFormatter{Header: true, Align: []Alignment{AlignLeft, AlignRight}}, rows, []string{
+			"Name   Qty  Note",
+			"-----  ---  ----",
+			"apple    3  red",
+			"日本    12",
+		}},
+		{TableFormatter{Header: true}, rows[:1], []string{
+			"Name  Qty  Note",
+		}},
+		{TableFormatter{Header: true, Border: BorderASCII, Align: []Alignment{AlignLeft, AlignRight}}, rows, []string{
+			"+-------+-----+------+",
+			"| Name  | Qty | Note |",
+			"+-------+-----+------+",
+			"| apple |   3 | red  |",
+			"| 日本  |  12 |      |",
+			"+-------+-----+------+",
+		}},
+		{TableFormatter{Header: true, Border: BorderUnicode, Align: []Alignment{AlignCenter}}, rows[:2], []string{
+			"┌───────┬─────┬──────┐",
+			"│ Name  │ Qty │ Note │",
+			"├───────┼─────┼──────┤",
+			"│ apple │ 3   │ red  │",
+			"└───────┴─────┴──────┘",
+		}},
+		{TableFormatter{Border: BorderASCII}, [][]string{{"a\nbb", "c"}}, []string{
+			"+----+---+",
+			"| a  | c |",
+			"| bb |   |",
+			"+----+---+",
+		}},
+		{TableFormatter{Border: BorderASCII, MaxWidth: 18}, [][]string{{"id", "a long description"}}, []string{
+			"+----+-----------+",
+			"| id | a long    |",
+			"|    | descripti |",
+			"|    | on        |",
+			"+----+-----------+",
+		}},
+		{TableFormatter{MaxWidth: 16}, [][]string{{"id", "a long description"}}, []string{
+			"id  a long",
+			"    description",
+		}},
+		{TableFormatter{MaxWidth: 14, Truncate: true}, [][]string{{"id", "a long description"}, {"7", "short"}}, []string{
+			"id  a long de…",
+			"7   short",
+		}},
+		{TableFormatter{MaxWidth: 12, Truncate: true}, [][]string{{"first column", "second column"}}, []string{
+			"firs…  seco…",
+		}},
+		{TableFormatter{MaxWidth: 7, Truncate: true}, [][]string{{"日本語", "x"}}, []string{
+			"日…   x",
+		}},
+	}
+
+	for _, sample := range samples {
+		if out := sample.formatter.Lines(sample.rows); !reflect.DeepEqual(out, sample.out) {
+			t.Errorf("got %q formatting %q %+v, expected %q", out, sample.rows, sample.formatter, sample.out)
+		}
+	}
+
+	if out := (TableFormatter{}).Format([][]string{{"a", "b"}, {"c"}}); out != "a  b\nc" {
+		t.Errorf("Format failed: %q", out)
+	}
+}
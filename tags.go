@@ -51,7 +51,8 @@ type PairKey = string
 // Keys may appear without values or in key=value format. Multiple keys or pairs
 // are separated by a comma. Values may not contain commas or double quotes.
 //
-// Leading and trailing space is trimmed from pair values.
+// Leading and trailing space is trimmed from keys and pair values and empty
+// pairs are skipped.
 // Specifying a pair with the same key multiple times adds values to an entry
 // under key in parsed [Values].
 //
@@ -92,6 +93,9 @@ type Tag struct {
 	Values
 }
 
+// tagSegments are segment options used to split a tag value into pairs.
+var tagSegments = SegmentOptions{Trim: true, SkipEmpty: true}
+
 // ErrTagNotFound is returned when tag named [Tag.TagKey] was not found in a
 // tag string literal.
 var ErrTagNotFound = errors.New("tag not found")
@@ -123,8 +127,9 @@ func (self *Tag) Parse(tag string) (err error) {
 	}
 	_, self.Raw, _ = strings.Cut(tag, "=")
 
-	for key, i := Segment(tag, self.Separator, 0); i > -1 || key != ""; key, i = Segment(tag, self.Separator, i) {
+	for key := range Segments(tag, self.Separator, tagSegments) {
 		var k, v, pair = strings.Cut(key, "=")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
 		if !self.validKey(k) {
 			return errors.New("invalid key: " + k)
 		}
@@ -38,3 +38,16 @@ func TestTag(t *testing.T) {
 		t.Fatal("First failed")
 	}
 }
+
+func TestTagSegments(t *testing.T) {
+	var config = &Tag{TagKey: "tag"}
+	if err := config.Parse(`tag:" key1 ,, key2 = value1 ,key2=value2,"`); err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Values) != 2 || !config.Values.Exists("key1") {
+		t.Fatalf("unexpected values %v", config.Values)
+	}
+	if v := config.Values["key2"]; len(v) != 2 || v[0] != "value1" || v[1] != "value2" {
+		t.Fatalf("unexpected key2 values %q", v)
+	}
+}
@@ -0,0 +1,21 @@
+package strutils
+
+import "testing"
+
+func TestTransliterate(t *testing.T) {
+	samples := []sample{
+		{"", ""},
+		{"plain ascii", "plain ascii"},
+		{"café", "cafe"},
+		{"Straße", "Strasse"},
+		{"Ærøskøbing", "AEroskobing"},
+		{"Đurđevac, Čakovec", "Durdevac, Cakovec"},
+		{"日本語 ñ", "日本語 n"},
+	}
+
+	for _, sample := range samples {
+		if out := transliterate(sample.str); out != sample.out {
+			t.Errorf("got %q from %q, expected %q", out, sample.str, sample.out)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Vedran Vuk. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package strutils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TruncateUnit specifies the unit in which [Truncate] measures width.
+type TruncateUnit int
+
+const (
+	// TruncateColumns measures display width in columns, see
+	// [DisplayWidth]. Strings are cut between grapheme clusters and ANSI
+	// escape sequences are never cut.
+	TruncateColumns TruncateUnit = iota
+	// TruncateRunes measures width in runes.
+	TruncateRunes
+	// TruncateBytes measures width in bytes of UTF-8 encoded text. Strings
+	// are cut between runes.
+	TruncateBytes
+)
+
+// EllipsisPosition specifies where [Truncate] removes text and places the
+// ellipsis.
+type EllipsisPosition int
+
+const (
+	// EllipsisEnd keeps the start of a string, as in "abc…".
+	EllipsisEnd EllipsisPosition = iota
+	// EllipsisStart keeps the end of a string, as in "…xyz".
+	EllipsisStart
+	// EllipsisMiddle keeps the start and the end of a string, as in
+	// "abc…xyz". If they can not be of equal width the start is wider.
+	EllipsisMiddle
+)
+
+// TruncateOptions define how [Truncate] shortens strings.
+type TruncateOptions struct {
+	// Ellipsis replaces removed text. If it is wider than the width strings
+	// are truncated to, it is omitted.
+	Ellipsis string
+
+	// Position is where text is removed.
+	Position EllipsisPosition
+
+	// Unit is the unit of width.
+	Unit TruncateUnit
+
+	// WordBoundary, if true, prefers to cut strings at white space between
+	// words rather than within a word. Words are cut only if no white space
+	// remains in kept text.
+	WordBoundary bool
+}
+
+// DefaultTruncateOptions truncate strings at the end to display width with a
+// "…" ellipsis.
+var DefaultTruncateOptions = TruncateOptions{Ellipsis: "…"}
+
+// Truncate returns s shortened to at most width units as defined by opts.
+//
+// If s is no wider than width it is returned unmodified. Otherwise text is
+// removed at the position defined by opts and replaced by the ellipsis so
+// that the result, including the ellipsis, is at most width wide. Multi-byte
+// UTF-8 sequences are never cut.
+//
+// ANSI escape sequences are never cut and occupy no width in any unit, as
+// with [WrapText]. A style set by SGR escape sequences in text removed before
+// the kept end of s is re-applied to it, and a style active at the end of
+// the result is reset.
+func Truncate(s string, width int, opts TruncateOptions) string {
+	if opts.measure(s) <= width {
+		return s
+	}
+	var ellipsis = opts.Ellipsis
+	if opts.measure(ellipsis) > width {
+		ellipsis = ""
+	}
+	var (
+		avail = max(width-opts.measure(ellipsis), 0)
+		out   string
+	)
+	switch opts.Position {
+	case EllipsisStart:
+		out = ellipsis + opts.tail(s, avail)
+	case EllipsisMiddle:
+		var head = (avail + 1) / 2
+		out = opts.head(s, head) + ellipsis + opts.tail(s, avail-head)
+	default:
+		out = opts.head(s, avail) + ellipsis
+	}
+	if sgrStyle("").scan(out) != "" {
+		out += ansiReset
+	}
+	return out
+}
+
+// head returns the longest prefix of s at most width wide.
+func (self TruncateOptions) head(s string, width int) string {
+	var (
+		n, w  = 0, 0
+		space = -1    // offset of the last white space before n
+		after = false // last rune before n is white space
+	)
+	for n < len(s) {
+		var size, uw = self.next(s[n:])
+		if w+uw > width {
+			break
+		}
+		if r, ok := visibleRune(s[n : n+size]); ok {
+			if after = unicode.IsSpace(r); after {
+				space = n
+			}
+		}
+		n, w = n+size, w+uw
+	}
+	if !self.WordBoundary || n == 0 || n == len(s) {
+		return s[:n]
+	}
+	if r, _ := visibleRune(s[n:]); !after && !unicode.IsSpace(r) && space >= 0 {
+		n = space
+	}
+	if t := strings.TrimRightFunc(s[:n], unicode.IsSpace); t != "" {
+		return t
+	}
+	return s[:n]
+}
+
+// tail returns the longest suffix of s at most width wide, led by the style
+// set by SGR escape sequences before it.
+func (self TruncateOptions) tail(s string, width int) string {
+	var (
+		n, w   = 0, self.measure(s)
+		before = false // last rune before n is white space
+	)
+	for n < len(s) && w > width {
+		var size, uw = self.next(s[n:])
+		if r, ok := visibleRune(s[n : n+size]); ok {
+			before = unicode.IsSpace(r)
+		}
+		n, w = n+size, w-uw
+	}
+	if r, _ := visibleRune(s[n:]); self.WordBoundary && n > 0 && !before && !unicode.IsSpace(r) {
+		for i := n; i < len(s); {
+			var size, _ = self.next(s[i:])
+			if r, ok := visibleRune(s[i : i+size]); ok && unicode.IsSpace(r) {
+				n = i
+				break
+			}
+			i += size
+		}
+	}
+	var t = s[n:]
+	if self.WordBoundary {
+		if trimmed := strings.TrimLeftFunc(t, unicode.IsSpace); trimmed != "" {
+			t = trimmed
+		}
+	}
+	if style := sgrStyle("").scan(s[:n]); style != "" {
+		return string(style) + t
+	}
+	return t
+}
+
+// measure returns the width of s.
+func (self TruncateOptions) measure(s string) (width int) {
+	if self.Unit == TruncateColumns {
+		return DisplayWidth(s)
+	}
+	for len(s) > 0 {
+		var size, w = self.next(s)
+		s, width = s[size:], width+w
+	}
+	return
+}
+
+// next returns the size and width of the unit at the start of s that may
+// not be cut. An ANSI escape sequence is a unit of no width.
+func (self TruncateOptions) next(s string) (size, width int) {
+	if self.Unit == TruncateColumns {
+		return nextCluster(s)
+	}
+	if size = ansiLen(s); size > 0 {
+		return size, 0
+	}
+	_, size = utf8.DecodeRuneInString(s)
+	if self.Unit == TruncateRunes {
+		return size, 1
+	}
+	return size, size
+}
+
+// visibleRune returns the first rune of s that is not part of an ANSI escape
+// sequence and true or 0 and false if there is none.
+func visibleRune(s string) (rune, bool) {
+	for len(s) > 0 {
+		if n := ansiLen(s); n > 0 {
+			s = s[n:]
+			continue
+		}
+		var r, _ = utf8.DecodeRuneInString(s)
+		return r, true
+	}
+	return 0, false
+}
@@ -0,0 +1,66 @@
+package strutils
+
+import "testing"
+
+func TestTruncate(t *testing.T) {
+	type truncateSample struct {
+		text  string
+		width int
+		opts  TruncateOptions
+		out   string
+	}
+	var (
+		def    = DefaultTruncateOptions
+		start  = TruncateOptions{Ellipsis: "…", Position: EllipsisStart}
+		middle = TruncateOptions{Ellipsis: "…", Position: EllipsisMiddle}
+		words  = TruncateOptions{Ellipsis: "...", WordBoundary: true}
+		runes  = TruncateOptions{Ellipsis: "…", Unit: TruncateRunes}
+		bytes  = TruncateOptions{Ellipsis: "…", Unit: TruncateBytes}
+	)
+	samples := []truncateSample{
+		{"", 0, def, ""},
+		{"abc", 3, def, "abc"},
+		{"abcdef", 4, def, "abc…"},
+		{"abcdef", 1, def, "…"},
+		{"abcdef", 0, def, ""},
+		{"abcdef", 4, TruncateOptions{}, "abcd"},
+		{"abcdef", 4, start, "…def"},
+		{"abcdefghij", 7, middle, "abc…hij"},
+		{"abcdefghij", 6, middle, "abc…ij"},
+		{"日本語テキスト", 5, def, "日本…"},
+		{"日本語テキスト", 6, def, "日本…"},
+		{"日本語テキスト", 6, start, "…スト"},
+		{"éééé", 3, def, "éé…"},
+		{"👍🏽👍🏽👍🏽", 5, def, "👍🏽👍🏽…"},
+		{"the quick brown fox", 15, words, "the quick..."},
+		{"the quick brown fox", 16, words, "the quick..."},
+		{"the quick brown fox", 13, words, "the quick..."},
+		{"extraordinarily", 8, words, "extra..."},
+		{"the quick brown fox", 12, TruncateOptions{Ellipsis: "…", Position: EllipsisStart, WordBoundary: true}, "…brown fox"},
+		{"the quick brown fox", 12, TruncateOptions{Ellipsis: "…", Position: EllipsisMiddle, WordBoundary: true}, "the…fox"},
+		{"čćžšđ", 4, runes, "čćž…"},
+		{"čćžšđ", 7, bytes, "čć…"},
+		{"čćžšđ", 6, bytes, "č…"},
+		{"čćžšđ", 2, bytes, "č"},
+		{"\x1b[31mabcdef\x1b[0m", 4, def, "\x1b[31mabc…\x1b[0m"},
+		{"\x1b[31mab\x1b[0mcdef", 4, def, "\x1b[31mab\x1b[0mc…"},
+		{"\x1b[31mabcdef\x1b[0m", 4, runes, "\x1b[31mabc…\x1b[0m"},
+		{"\x1b[31mabcdef\x1b[0m", 6, runes, "\x1b[31mabcdef\x1b[0m"},
+		{"\x1b[31mabcdef\x1b[0m", 6, bytes, "\x1b[31mabcdef\x1b[0m"},
+		{"\x1b[31mabcdef\x1b[0m", 5, bytes, "\x1b[31mab…\x1b[0m"},
+		{"\x1b[31mabcdef\x1b[0m", 2, bytes, "\x1b[31mab\x1b[0m"},
+		{"\x1b[31mab\x1b[0mcdef", 3, runes, "\x1b[31mab\x1b[0m…"},
+		{"ab\x1b[31mcdef", 4, TruncateOptions{Ellipsis: "…", Position: EllipsisStart, Unit: TruncateRunes}, "…\x1b[31mdef\x1b[0m"},
+		{"\x1b]8;;http://x.y/a b\x07link text\x1b]8;;\x07", 6, words, "\x1b]8;;http://x.y/a b\x07lin..."},
+		{"\x1b]8;;http://x.y/a b\x07link text\x1b]8;;\x07", 8, TruncateOptions{Ellipsis: "…", Unit: TruncateBytes, WordBoundary: true}, "\x1b]8;;http://x.y/a b\x07link…"},
+	}
+
+	for _, sample := range samples {
+		if out := Truncate(sample.text, sample.width, sample.opts); out != sample.out {
+			t.Errorf("got %q truncating %q to %d %+v, expected %q", out, sample.text, sample.width, sample.opts, sample.out)
+		}
+		if w := sample.opts.measure(sample.out); w > max(sample.width, 0) {
+			t.Errorf("%q is %d wide, expected at most %d", sample.out, w, sample.width)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package strutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	type widthSample struct {
+		s     string
+		width int
+	}
+	samples := []widthSample{
+		{"", 0},
+		{"hello", 5},
+		{"\t\x00\x1b", 0},
+		{"čćžšđ", 5},
+		{"привет", 6},
+		{"日本語", 6},
+		{"ｈｅｌｌｏ", 10},
+		{"ﾊﾛｰ", 3},
+		{"한국어", 6},
+		{"\u1100\u1161\u11a8", 2},
+		{"e\u0301", 1},
+		{"a\u0300\u0301\u0302b", 2},
+		{"\u200b", 0},
+		{"😀", 2},
+		{"👍🏽", 2},
+		{"❤", 1},
+		{"❤\ufe0f", 2},
+		{"👨\u200d👩\u200d👧", 2},
+		{"🇭🇷", 2},
+		{"🇭🇷🇩🇪", 4},
+		{"→", 1},
+		{"\xff", 1},
+	}
+
+	for _, sample := range samples {
+		if width := DisplayWidth(sample.s); width != sample.width {
+			t.Errorf("got width %d for %q, expected %d", width, sample.s, sample.width)
+		}
+	}
+
+	if n := testing.AllocsPerRun(100, func() { DisplayWidth("日本語 👨\u200d👩\u200d👧 e\u0301") }); n != 0 {
+		t.Errorf("got %v allocs, expected 0", n)
+	}
+}
+
+func TestRuneWidth(t *testing.T) {
+	type runeSample struct {
+		r     rune
+		width int
+	}
+	samples := []runeSample{
+		{'a', 1},
+		{'\n', 0},
+		{0x85, 0},
+		{'é', 1},
+		{0x301, 0},
+		{0x200d, 0},
+		{0xfe0f, 0},
+		{'日', 2},
+		{0x3000, 2},
+		{0xff01, 2},
+		{0x20000, 2},
+		{'😀', 2},
+		{'Ω', 1},
+	}
+
+	for _, sample := range samples {
+		if width := RuneWidth(sample.r); width != sample.width {
+			t.Errorf("got width %d for %U, expected %d", width, sample.r, sample.width)
+		}
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	type graphemeSample struct {
+		s   string
+		out []string
+	}
+	samples := []graphemeSample{
+		{"", nil},
+		{"ab", []string{"a", "b"}},
+		{"a\r\nb", []string{"a", "\r\n", "b"}},
+		{"e\u0301x", []string{"e\u0301", "x"}},
+		{"👨\u200d👩\u200d👧!", []string{"👨\u200d👩\u200d👧", "!"}},
+		{"🇭🇷🇩🇪🇫", []string{"🇭🇷", "🇩🇪", "🇫"}},
+		{"👍🏽👍", []string{"👍🏽", "👍"}},
+		{"\u1100\u1161\u11a8가", []string{"\u1100\u1161\u11a8", "가"}},
+	}
+
+	for _, sample := range samples {
+		var out []string
+		for g := range Graphemes(sample.s) {
+			out = append(out, g)
+		}
+		if !reflect.DeepEqual(out, sample.out) {
+			t.Errorf("got %q from %q, expected %q", out, sample.s, sample.out)
+		}
+	}
+}
@@ -0,0 +1,843 @@
+// Copyright 2026 Vedran Vuk. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package strutils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WildcardOptions are options for [CompileWildcard].
+type WildcardOptions struct {
+	// CaseSensitive, if true, makes matching case-sensitive.
+	//
+	// Default: false
+	CaseSensitive bool
+
+	// Path, if true, enables path-aware matching where "/" separates path
+	// segments. "*", "?" and character classes then never match a "/", and
+	// "**" forming a whole segment matches any number of segments, including
+	// none, so that "a/**/b" matches "a/b" and "a/x/y/b".
+	//
+	// If false, "**" is equal to "*".
+	//
+	// Default: false
+	Path bool
+}
+
+// Wildcard is a compiled wildcard pattern.
+//
+// Pattern syntax:
+//
+//	pattern meaning
+//	*       matches any sequence of characters.
+//	**      matches any sequence of characters including "/" if
+//	        WildcardOptions.Path is true, see WildcardOptions.
+//	?       matches any single character.
+//	[abc]   matches a single character from the set.
+//	[a-z]   matches a single character from the range.
+//	[!a-z]  matches a single character not in the set, "^" may be used
+//	        instead of "!". A "]" right after the opening bracket or the
+//	        negation is a literal.
+//	{a,b}   matches any of comma separated alternatives which may contain
+//	        any pattern syntax, including nested alternatives.
+//	\x      matches x literally.
+//
+// Any other character matches itself. Characters are runes. An empty pattern
+// matches only an empty string.
+//
+// A Wildcard is safe for concurrent use.
+type Wildcard struct {
+	pattern string
+	options WildcardOptions
+	alts    [][]wildToken
+}
+
+// WildcardCapture is a part of a matched string captured by a wildcard
+// token.
+type WildcardCapture struct {
+	// Start is the byte offset of captured text in the matched string.
+	Start int
+	// End is the byte offset just after captured text in the matched string.
+	End int
+	// Text is the captured text.
+	Text string
+}
+
+// wildKind is a kind of wildcard token.
+type wildKind int
+
+const (
+	// wildLiteral matches a literal.
+	wildLiteral wildKind = iota
+	// wildAny matches any single rune.
+	wildAny
+	// wildClass matches a single rune from a class.
+	wildClass
+	// wildStar matches any number of runes.
+	wildStar
+	// wildGlobstar matches any number of runes including path separators.
+	wildGlobstar
+	// wildGlobstarSlash matches any number of whole path segments including
+	// their trailing separator, or nothing.
+	wildGlobstarSlash
+)
+
+// wildToken is a compiled wildcard token.
+type wildToken struct {
+	kind   wildKind
+	lit    string      // literal text for wildLiteral
+	ranges []wildRange // ranges for wildClass
+	negate bool        // negated wildClass
+	group  int         // capture index, -1 for wildLiteral
+}
+
+// wildRange is an inclusive rune range of a class.
+type wildRange struct{ lo, hi rune }
+
+// WildcardError describes a syntax error in a wildcard pattern.
+type WildcardError struct {
+	// Pattern is the malformed pattern.
+	Pattern string
+	// Pos is the byte offset of the error in Pattern.
+	Pos int
+	// Message describes the error.
+	Message string
+}
+
+// Error implements error on WildcardError.
+func (self *WildcardError) Error() string {
+	return "wildcard: " + self.Message + " at " + strconv.Itoa(self.Pos) + " in " + strconv.Quote(self.Pattern)
+}
+
+// maxWildcardAlternatives limits the number of alternatives a pattern with
+// alternation expands into.
+const maxWildcardAlternatives = 1024
+
+// CompileWildcard compiles pattern using options and returns a Wildcard or
+// an error if pattern is malformed.
+func CompileWildcard(pattern string, options WildcardOptions) (*Wildcard, error) {
+	var c = &wildCompiler{pattern: pattern, options: options}
+	alts, err := c.parse(false)
+	if err != nil {
+		return nil, err
+	}
+	for i, alt := range alts {
+		alts[i] = normalizeWildTokens(alt)
+		var group = 0
+		for j := range alts[i] {
+			if alts[i][j].kind == wildLiteral {
+				alts[i][j].group = -1
+				continue
+			}
+			alts[i][j].group = group
+			group++
+		}
+	}
+	return &Wildcard{pattern: pattern, options: options, alts: alts}, nil
+}
+
+// MustCompileWildcard is like [CompileWildcard] but panics on error.
+func MustCompileWildcard(pattern string, options WildcardOptions) *Wildcard {
+	w, err := CompileWildcard(pattern, options)
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
+// String returns the source pattern.
+func (self *Wildcard) String() string { return self.pattern }
+
+// Match returns true if s matches the pattern.
+// It does not allocate.
+func (self *Wildcard) Match(s string) bool {
+	for _, alt := range self.alts {
+		if self.match(alt, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Captures returns the parts of s matched by each wildcard token and true if
+// s matches the pattern or nil and false otherwise.
+//
+// Capturing tokens are "*", "**", "?" and character classes, in order as
+// they appear in the pattern. Adjacent stars capture as a single token. If
+// the pattern contains alternation captures are of the alternative that
+// matched.
+func (self *Wildcard) Captures(s string) ([]WildcardCapture, bool) {
+	for _, alt := range self.alts {
+		var n = 0
+		for _, t := range alt {
+			if t.group >= 0 {
+				n++
+			}
+		}
+		var (
+			caps   = make([]WildcardCapture, n)
+			failed = newWildMemo(len(alt), len(s))
+		)
+		if self.capture(alt, 0, s, 0, caps, failed) {
+			for i := range caps {
+				caps[i].Text = s[caps[i].Start:caps[i].End]
+			}
+			return caps, true
+		}
+	}
+	return nil, false
+}
+
+// Replace returns template with capture references replaced by captures of s
+// and true if s matches the pattern or an empty string and false otherwise.
+//
+// "$n" or "${n}" is replaced with the n-th capture as returned by
+// [Wildcard.Captures], starting from 1. "$0" is replaced with s, "$$" with a
+// single "$". References to captures that do not exist are replaced with an
+// empty string. A "$" not followed by a reference is copied as is.
+//
+// For instance, pattern "*.txt" matched against "notes.txt" renders template
+// "out/$1.bak" as "out/notes.bak".
+func (self *Wildcard) Replace(s, template string) (string, bool) {
+	var caps, ok = self.Captures(s)
+	if !ok {
+		return "", false
+	}
+	var b strings.Builder
+	b.Grow(len(template))
+	for i := 0; i < len(template); i++ {
+		if template[i] != '$' || i == len(template)-1 {
+			b.WriteByte(template[i])
+			continue
+		}
+		if template[i+1] == '$' {
+			b.WriteByte('$')
+			i++
+			continue
+		}
+		var n, size, ok = parseWildcardRef(template[i+1:])
+		if !ok {
+			b.WriteByte('$')
+			continue
+		}
+		switch {
+		case n == 0:
+			b.WriteString(s)
+		case n <= len(caps):
+			b.WriteString(caps[n-1].Text)
+		}
+		i += size
+	}
+	return b.String(), true
+}
+
+// parseWildcardRef parses a capture reference "n" or "{n}" at the start of s
+// and returns capture number, reference length and true or false if s does
+// not start with a reference.
+func parseWildcardRef(s string) (n, size int, ok bool) {
+	var braced = s[0] == '{'
+	if braced {
+		size++
+	}
+	var start = size
+	for ; size < len(s) && IsDigit(s[size]); size++ {
+		if n = n*10 + int(s[size]-'0'); n > 1<<20 {
+			return 0, 0, false
+		}
+	}
+	if size == start {
+		return 0, 0, false
+	}
+	if braced {
+		if size == len(s) || s[size] != '}' {
+			return 0, 0, false
+		}
+		size++
+	}
+	return n, size, true
+}
+
+// match returns true if s matches tokens.
+//
+// A mismatch extends only the last star and retries tokens after it. A star
+// that may not cross "/" is extended within its segment, after which the last
+// star that may cross "/" is extended. Matching takes at most quadratic time.
+func (self *Wildcard) match(tokens []wildToken, s string) bool {
+	var (
+		ti, si       = 0, 0
+		starT, starS = -1, 0 // tokens after and offset of the last star
+		globT, globS = -1, 0 // tokens after and offset of the last crossing star
+		globSlash    = false // last crossing star matches whole segments
+	)
+	for {
+		if ti < len(tokens) {
+			switch t := &tokens[ti]; t.kind {
+			case wildLiteral:
+				if n, ok := self.prefix(s[si:], t.lit); ok {
+					ti, si = ti+1, si+n
+					continue
+				}
+			case wildAny, wildClass:
+				r, size := utf8.DecodeRuneInString(s[si:])
+				if size > 0 && (!self.options.Path || r != '/') && (t.kind == wildAny || self.inClass(t, r)) {
+					ti, si = ti+1, si+size
+					continue
+				}
+			case wildStar, wildGlobstar:
+				if t.kind == wildGlobstar || !self.options.Path {
+					if ti == len(tokens)-1 {
+						return true
+					}
+					globT, globS, globSlash, starT = ti+1, si, false, -1
+				} else {
+					if ti == len(tokens)-1 && strings.IndexByte(s[si:], '/') < 0 {
+						return true
+					}
+					starT, starS = ti+1, si
+				}
+				ti++
+				continue
+			case wildGlobstarSlash:
+				// Unlike other crossing stars this one can not extend a star
+				// before it which therefore is extended first.
+				globT, globS, globSlash = ti+1, si, true
+				ti++
+				continue
+			}
+		} else if si == len(s) {
+			return true
+		}
+		if starT >= 0 && starS < len(s) && s[starS] != '/' {
+			_, size := utf8.DecodeRuneInString(s[starS:])
+			starS += size
+			ti, si = starT, starS
+			continue
+		}
+		starT = -1
+		switch {
+		case globT < 0:
+			return false
+		case globSlash:
+			var n = strings.IndexByte(s[globS:], '/')
+			if n < 0 {
+				return false
+			}
+			globS += n + 1
+		default:
+			if globS == len(s) {
+				return false
+			}
+			_, size := utf8.DecodeRuneInString(s[globS:])
+			globS += size
+		}
+		ti, si = globT, globS
+	}
+}
+
+// capture returns true if s from offset si matches tokens from index ti and
+// stores captures of capturing tokens into caps.
+//
+// Offsets from which tokens failed to match are recorded in failed and not
+// tried again so that matching takes time proportional to the number of
+// tokens times the length of s.
+func (self *Wildcard) capture(tokens []wildToken, ti int, s string, si int, caps []WildcardCapture, failed wildMemo) bool {
+	if failed.has(ti, si) {
+		return false
+	}
+	var entryT, entryS = ti, si
+	for ; ti < len(tokens); ti++ {
+		switch t := &tokens[ti]; t.kind {
+		case wildLiteral:
+			n, ok := self.prefix(s[si:], t.lit)
+			if !ok {
+				failed.set(entryT, entryS)
+				return false
+			}
+			si += n
+		case wildAny, wildClass:
+			r, size := utf8.DecodeRuneInString(s[si:])
+			if size == 0 || self.options.Path && r == '/' || t.kind == wildClass && !self.inClass(t, r) {
+				failed.set(entryT, entryS)
+				return false
+			}
+			caps[t.group] = WildcardCapture{Start: si, End: si + size}
+			si += size
+		case wildStar, wildGlobstar, wildGlobstarSlash:
+			var cross = t.kind != wildStar || !self.options.Path
+			if ti == len(tokens)-1 && t.kind != wildGlobstarSlash {
+				if !cross && strings.IndexByte(s[si:], '/') >= 0 {
+					failed.set(entryT, entryS)
+					return false
+				}
+				caps[t.group] = WildcardCapture{Start: si, End: len(s)}
+				return true
+			}
+			// Tokens from this star at any offset tried below fail if
+			// tokens after it fail at every offset after that one, so
+			// offsets of stars already known to fail end the search.
+			var i = si
+			for {
+				if i > si && failed.has(ti, i) {
+					break
+				}
+				if self.capture(tokens, ti+1, s, i, caps, failed) {
+					caps[t.group] = WildcardCapture{Start: si, End: i}
+					return true
+				}
+				if t.kind == wildGlobstarSlash {
+					var n = strings.IndexByte(s[i:], '/')
+					if n < 0 {
+						break
+					}
+					i += n + 1
+					continue
+				}
+				if i == len(s) || !cross && s[i] == '/' {
+					break
+				}
+				_, size := utf8.DecodeRuneInString(s[i:])
+				i += size
+			}
+			for j := si; j <= i; j++ {
+				if t.kind != wildGlobstarSlash || j == si || s[j-1] == '/' {
+					failed.set(ti, j)
+				}
+			}
+			failed.set(entryT, entryS)
+			return false
+		}
+	}
+	if si != len(s) {
+		failed.set(entryT, entryS)
+		return false
+	}
+	return true
+}
+
+// wildMemo is a set of token index and string offset pairs.
+type wildMemo struct {
+	bits   []uint64
+	stride int
+}
+
+// newWildMemo returns a wildMemo for tokens tokens and a string of length n.
+func newWildMemo(tokens, n int) wildMemo {
+	var stride = n + 1
+	return wildMemo{bits: make([]uint64, ((tokens+1)*stride+63)/64), stride: stride}
+}
+
+// has returns true if the pair of token index ti and offset si is in the set.
+func (self wildMemo) has(ti, si int) bool {
+	var k = ti*self.stride + si
+	return self.bits[k>>6]&(1<<(k&63)) != 0
+}
+
+// set adds the pair of token index ti and offset si to the set.
+func (self wildMemo) set(ti, si int) {
+	var k = ti*self.stride + si
+	self.bits[k>>6] |= 1 << (k & 63)
+}
+
+// prefix returns the length of prefix of s that matches lit and true or 0
+// and false if s does not begin with lit.
+func (self *Wildcard) prefix(s, lit string) (int, bool) {
+	if self.options.CaseSensitive {
+		return len(lit), strings.HasPrefix(s, lit)
+	}
+	return prefixFold(s, lit)
+}
+
+// inClass returns true if r matches class token t.
+func (self *Wildcard) inClass(t *wildToken, r rune) bool {
+	var in = inWildRanges(t.ranges, r)
+	if !in && !self.options.CaseSensitive {
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if in = inWildRanges(t.ranges, f); in {
+				break
+			}
+		}
+	}
+	return in != t.negate
+}
+
+// inWildRanges returns true if r is in any of ranges.
+func inWildRanges(ranges []wildRange, r rune) bool {
+	for _, rng := range ranges {
+		if r >= rng.lo && r <= rng.hi {
+			return true
+		}
+	}
+	return false
+}
+
+// wildCompiler parses a wildcard pattern.
+type wildCompiler struct {
+	pattern string
+	options WildcardOptions
+	pos     int
+	// groups are the alternation groups being parsed, innermost last.
+	groups []wildGroup
+}
+
+// wildGroup is an alternation group being parsed.
+type wildGroup struct {
+	// segStart and segEnd are true if the group starts and ends at a path
+	// segment boundary.
+	segStart, segEnd bool
+}
+
+// error returns a *WildcardError at pos.
+func (self *wildCompiler) error(pos int, message string) error {
+	return &WildcardError{Pattern: self.pattern, Pos: pos, Message: message}
+}
+
+// parse parses pattern from current position until the end of pattern or,
+// if nested, until an unescaped "," or "}" and returns the alternatives the
+// parsed sequence expands to.
+func (self *wildCompiler) parse(nested bool) ([][]wildToken, error) {
+	var alts = [][]wildToken{nil}
+	for self.pos < len(self.pattern) {
+		var c = self.pattern[self.pos]
+		switch {
+		case nested && (c == ',' || c == '}'):
+			return alts, nil
+		case c == '{':
+			var start = self.pos
+			var group, err = self.parseGroup()
+			if err != nil {
+				return nil, err
+			}
+			if len(alts)*len(group) > maxWildcardAlternatives {
+				return nil, self.error(start, "too many alternatives")
+			}
+			var product = make([][]wildToken, 0, len(alts)*len(group))
+			for _, a := range alts {
+				for _, g := range group {
+					product = append(product, append(append([]wildToken(nil), a...), g...))
+				}
+			}
+			alts = product
+		default:
+			var t, err = self.parseToken()
+			if err != nil {
+				return nil, err
+			}
+			for i := range alts {
+				alts[i] = append(alts[i], t)
+			}
+		}
+	}
+	return alts, nil
+}
+
+// parseGroup parses an alternation group at current position.
+func (self *wildCompiler) parseGroup() (group [][]wildToken, err error) {
+	var start = self.pos
+	var end = wildGroupEnd(self.pattern, start)
+	self.groups = append(self.groups, wildGroup{
+		segStart: self.segBoundary(start-1, false),
+		segEnd:   end >= 0 && self.segBoundary(end, true),
+	})
+	defer func() { self.groups = self.groups[:len(self.groups)-1] }()
+	self.pos++
+	for {
+		var alts [][]wildToken
+		if alts, err = self.parse(true); err != nil {
+			return nil, err
+		}
+		group = append(group, alts...)
+		if self.pos >= len(self.pattern) {
+			return nil, self.error(start, "unterminated alternation")
+		}
+		if self.pattern[self.pos] == '}' {
+			self.pos++
+			return group, nil
+		}
+		self.pos++
+	}
+}
+
+// segBoundary returns true if the pattern byte at i, which follows a token
+// if after is true or precedes it otherwise, is at a path segment boundary:
+// a "/", the start or end of pattern or the edge of an alternative of a group
+// that is itself at a segment boundary.
+func (self *wildCompiler) segBoundary(i int, after bool) bool {
+	var p = self.pattern
+	switch {
+	case i < 0 || i >= len(p) || p[i] == '/':
+		return true
+	case len(self.groups) == 0:
+		return false
+	case p[i] == ',' || after && p[i] == '}' || !after && p[i] == '{':
+		var g = self.groups[len(self.groups)-1]
+		return after && g.segEnd || !after && g.segStart
+	}
+	return false
+}
+
+// wildGroupEnd returns the index just after the "}" that closes the
+// alternation group opened at index start of pattern p or -1 if the group is
+// not closed.
+func wildGroupEnd(p string, start int) int {
+	var depth = 0
+	for i := start; i < len(p); i++ {
+		switch p[i] {
+		case '\\':
+			i++
+		case '[':
+			// Skip a class, a "]" right after the opening bracket or the
+			// negation is a literal.
+			if i++; i < len(p) && (p[i] == '!' || p[i] == '^') {
+				i++
+			}
+			if i < len(p) && p[i] == '\\' {
+				i++
+			}
+			for i++; i < len(p) && p[i] != ']'; i++ {
+				if p[i] == '\\' {
+					i++
+				}
+			}
+		case '{':
+			depth++
+		case '}':
+			if depth--; depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return -1
+}
+
+// parseToken parses a single non-alternation token at current position.
+func (self *wildCompiler) parseToken() (t wildToken, err error) {
+	var p = self.pattern
+	switch p[self.pos] {
+	case '*':
+		var start = self.pos
+		for self.pos < len(p) && p[self.pos] == '*' {
+			self.pos++
+		}
+		if !self.options.Path || self.pos-start < 2 {
+			return wildToken{kind: wildStar}, nil
+		}
+		var (
+			segStart = self.segBoundary(start-1, false)
+			segEnd   = self.segBoundary(self.pos, true)
+		)
+		if !segStart || !segEnd {
+			return wildToken{kind: wildStar}, nil
+		}
+		if self.pos < len(p) && p[self.pos] == '/' {
+			self.pos++
+			return wildToken{kind: wildGlobstarSlash}, nil
+		}
+		return wildToken{kind: wildGlobstar}, nil
+	case '?':
+		self.pos++
+		return wildToken{kind: wildAny}, nil
+	case '[':
+		return self.parseClass()
+	case '\\':
+		if self.pos++; self.pos >= len(p) {
+			return t, self.error(self.pos-1, "trailing backslash")
+		}
+	}
+	var _, size = utf8.DecodeRuneInString(p[self.pos:])
+	t = wildToken{kind: wildLiteral, lit: p[self.pos : self.pos+size]}
+	self.pos += size
+	return t, nil
+}
+
+// parseClass parses a character class at current position.
+func (self *wildCompiler) parseClass() (t wildToken, err error) {
+	var (
+		p     = self.pattern
+		start = self.pos
+	)
+	t.kind = wildClass
+	if self.pos++; self.pos < len(p) && (p[self.pos] == '!' || p[self.pos] == '^') {
+		t.negate = true
+		self.pos++
+	}
+	for first := true; ; first = false {
+		if self.pos >= len(p) {
+			return t, self.error(start, "unterminated class")
+		}
+		if p[self.pos] == ']' && !first {
+			self.pos++
+			return t, nil
+		}
+		var (
+			lo, hi rune
+			item   = self.pos
+		)
+		if lo, err = self.classRune(); err != nil {
+			return
+		}
+		hi = lo
+		if self.pos+1 < len(p) && p[self.pos] == '-' && p[self.pos+1] != ']' {
+			self.pos++
+			if hi, err = self.classRune(); err != nil {
+				return
+			}
+			if hi < lo {
+				return t, self.error(item, "invalid class range")
+			}
+		}
+		t.ranges = append(t.ranges, wildRange{lo, hi})
+	}
+}
+
+// classRune returns the possibly escaped rune at current position.
+func (self *wildCompiler) classRune() (rune, error) {
+	if self.pattern[self.pos] == '\\' {
+		if self.pos++; self.pos >= len(self.pattern) {
+			return 0, self.error(self.pos-1, "trailing backslash")
+		}
+	}
+	var r, size = utf8.DecodeRuneInString(self.pattern[self.pos:])
+	self.pos += size
+	return r, nil
+}
+
+// normalizeWildTokens merges adjacent literals and adjacent stars.
+func normalizeWildTokens(tokens []wildToken) (out []wildToken) {
+	for _, t := range tokens {
+		if n := len(out); n > 0 {
+			var last = &out[n-1]
+			switch {
+			case t.kind == wildLiteral && last.kind == wildLiteral:
+				last.lit += t.lit
+				continue
+			case t.kind == wildStar && (last.kind == wildStar || last.kind == wildGlobstar):
+				continue
+			case t.kind == wildGlobstar && last.kind == wildStar:
+				last.kind = wildGlobstar
+				continue
+			}
+		}
+		out = append(out, t)
+	}
+	return
+}
+
+// ValidateWildcard returns a [*WildcardError] if pattern is malformed or nil
+// if it is valid. See [Wildcard] for pattern syntax.
+func ValidateWildcard(pattern string) error {
+	_, err := CompileWildcard(pattern, WildcardOptions{})
+	return err
+}
+
+// WildcardRegexp returns an anchored regular expression, in syntax accepted
+// by the regexp package, that matches the same strings pattern compiled with
+// options matches, or an error if pattern is malformed.
+//
+// Patterns using only "*" and "?" are also valid [MatchesWildcard] patterns
+// which are matched case-insensitively, except that MatchesWildcard never
+// matches an empty text.
+func WildcardRegexp(pattern string, options WildcardOptions) (string, error) {
+	var w, err = CompileWildcard(pattern, options)
+	if err != nil {
+		return "", err
+	}
+	return w.Regexp(), nil
+}
+
+// Regexp returns an anchored regular expression, in syntax accepted by the
+// regexp package, that matches the same strings self matches.
+func (self *Wildcard) Regexp() string {
+	var b strings.Builder
+	b.WriteString("(?s")
+	if !self.options.CaseSensitive {
+		b.WriteString("i")
+	}
+	b.WriteString(")^(?:")
+	for i, alt := range self.alts {
+		if i > 0 {
+			b.WriteByte('|')
+		}
+		for j := range alt {
+			self.writeRegexp(&b, &alt[j])
+		}
+	}
+	b.WriteString(")$")
+	return b.String()
+}
+
+// writeRegexp writes regular expression equivalent of t to b.
+func (self *Wildcard) writeRegexp(b *strings.Builder, t *wildToken) {
+	var any = "."
+	if self.options.Path {
+		any = "[^/]"
+	}
+	switch t.kind {
+	case wildLiteral:
+		b.WriteString(regexp.QuoteMeta(t.lit))
+	case wildAny:
+		b.WriteString(any)
+	case wildStar:
+		b.WriteString(any + "*")
+	case wildGlobstar:
+		b.WriteString(".*")
+	case wildGlobstarSlash:
+		b.WriteString("(?:.*/)?")
+	case wildClass:
+		b.WriteByte('[')
+		if t.negate {
+			b.WriteByte('^')
+			if self.options.Path {
+				b.WriteByte('/')
+			}
+		}
+		var empty = true
+		for _, rng := range t.ranges {
+			if self.options.Path && !t.negate && rng.lo <= '/' && rng.hi >= '/' {
+				// Classes never match a separator in path mode.
+				if rng.lo < '/' {
+					writeRegexpRange(b, rng.lo, '/'-1)
+					empty = false
+				}
+				if rng.hi > '/' {
+					writeRegexpRange(b, '/'+1, rng.hi)
+					empty = false
+				}
+				continue
+			}
+			writeRegexpRange(b, rng.lo, rng.hi)
+			empty = false
+		}
+		if empty {
+			// A class that can match nothing.
+			b.WriteString(`^\x00-\x{10FFFF}`)
+		}
+		b.WriteByte(']')
+	}
+}
+
+// writeRegexpRange writes a regular expression class range lo-hi to b.
+func writeRegexpRange(b *strings.Builder, lo, hi rune) {
+	writeRegexpClassRune(b, lo)
+	if hi != lo {
+		b.WriteByte('-')
+		writeRegexpClassRune(b, hi)
+	}
+}
+
+// writeRegexpClassRune writes r escaped for use inside a regular expression
+// class to b.
+func writeRegexpClassRune(b *strings.Builder, r rune) {
+	if r < utf8.RuneSelf && !IsAlphanumeric(byte(r)) {
+		b.WriteString(`\x{`)
+		b.WriteString(strconv.FormatInt(int64(r), 16))
+		b.WriteByte('}')
+		return
+	}
+	b.WriteRune(r)
+}
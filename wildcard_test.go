@@ -0,0 +1,331 @@
+package strutils
+
+import (
+	"errors"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+type wildcardSample struct {
+	pattern string
+	options WildcardOptions
+	str     string
+	match   bool
+}
+
+var wildcardSamples = []wildcardSample{
+	{"", WildcardOptions{}, "", true},
+	{"", WildcardOptions{}, "a", false},
+	{"*", WildcardOptions{}, "", true},
+	{"*", WildcardOptions{}, "anything", true},
+	{"?ic*n", WildcardOptions{}, "Dickson", true},
+	{"se?s*le", WildcardOptions{}, "Sensible", true},
+	{"?hi*IswrongO", WildcardOptions{}, "ThisIsWrong", false},
+	{"Si?fero*ka?epe?l", WildcardOptions{}, "Sinferopopokatepetl", true},
+	{"*a*b*c*", WildcardOptions{}, "xaybzc", true},
+	{"*a*b*c*", WildcardOptions{}, "xaybz", false},
+	{"ABC", WildcardOptions{}, "abc", true},
+	{"ABC", WildcardOptions{CaseSensitive: true}, "abc", false},
+	{"straße", WildcardOptions{}, "STRAẞE", true},
+	{"čćž?", WildcardOptions{}, "ČĆŽš", true},
+	{"[a-c]x", WildcardOptions{}, "bx", true},
+	{"[a-c]x", WildcardOptions{}, "dx", false},
+	{"[a-c]x", WildcardOptions{}, "Bx", true},
+	{"[a-c]x", WildcardOptions{CaseSensitive: true}, "Bx", false},
+	{"[!a-c]x", WildcardOptions{}, "dx", true},
+	{"[^a-c]x", WildcardOptions{}, "ax", false},
+	{"[]]", WildcardOptions{}, "]", true},
+	{"[!]]", WildcardOptions{}, "]", false},
+	{"[a-]", WildcardOptions{}, "-", true},
+	{"[\\]x]", WildcardOptions{}, "]", true},
+	{"file.{go,txt}", WildcardOptions{}, "file.go", true},
+	{"file.{go,txt}", WildcardOptions{}, "file.txt", true},
+	{"file.{go,txt}", WildcardOptions{}, "file.md", false},
+	{"{a,b{c,d}}e", WildcardOptions{}, "bde", true},
+	{"{a,b{c,d}}e", WildcardOptions{}, "be", false},
+	{"{,x}y", WildcardOptions{}, "y", true},
+	{"{*.go,[0-9]?}", WildcardOptions{}, "7z", true},
+	{"a,b}", WildcardOptions{}, "a,b}", true},
+	{"\\*", WildcardOptions{}, "*", true},
+	{"\\*", WildcardOptions{}, "x", false},
+	{"a\\?", WildcardOptions{}, "a?", true},
+	{"\\{a,b\\}", WildcardOptions{}, "{a,b}", true},
+	{"*.go", WildcardOptions{}, "dir/file.go", true},
+	{"*.go", WildcardOptions{Path: true}, "dir/file.go", false},
+	{"*/*.go", WildcardOptions{Path: true}, "dir/file.go", true},
+	{"?", WildcardOptions{Path: true}, "/", false},
+	{"[!a]", WildcardOptions{Path: true}, "/", false},
+	{"a/*", WildcardOptions{Path: true}, "a/b/c", false},
+	{"**/*.go", WildcardOptions{Path: true}, "file.go", true},
+	{"**/*.go", WildcardOptions{Path: true}, "a/b/file.go", true},
+	{"a/**/b", WildcardOptions{Path: true}, "a/b", true},
+	{"a/**/b", WildcardOptions{Path: true}, "a/x/y/b", true},
+	{"a/**/b", WildcardOptions{Path: true}, "a/x/y/c", false},
+	{"a/**", WildcardOptions{Path: true}, "a/x/y", true},
+	{"a/**", WildcardOptions{Path: true}, "b/x/y", false},
+	{"a**b", WildcardOptions{Path: true}, "axyb", true},
+	{"a**b", WildcardOptions{Path: true}, "ax/yb", false},
+	{"a**b", WildcardOptions{}, "ax/yb", true},
+	{"a{**,x}", WildcardOptions{Path: true}, "ab/c", false},
+	{"a{**,x}", WildcardOptions{Path: true}, "abc", true},
+	{"{**,x}b", WildcardOptions{Path: true}, "q/zb", false},
+	{"{**,x}b", WildcardOptions{Path: true}, "qzb", true},
+	{"a/{**,x}", WildcardOptions{Path: true}, "a/b/c", true},
+	{"{x,{y,**}}/b", WildcardOptions{Path: true}, "p/q/b", true},
+	{"{x,{y,**}}z/b", WildcardOptions{Path: true}, "p/qz/b", false},
+	{"a/{[}],**}/b", WildcardOptions{Path: true}, "a/p/q/b", true},
+	{"src/{**/,}*.go", WildcardOptions{Path: true}, "src/main.go", true},
+	{"src/{**/,}*.go", WildcardOptions{Path: true}, "src/a/b/main.go", true},
+}
+
+func TestWildcard(t *testing.T) {
+	for _, sample := range wildcardSamples {
+		w, err := CompileWildcard(sample.pattern, sample.options)
+		if err != nil {
+			t.Errorf("unexpected error compiling %q: %v", sample.pattern, err)
+			continue
+		}
+		if match := w.Match(sample.str); match != sample.match {
+			t.Errorf("got %t matching %q against %q %+v, expected %t",
+				match, sample.str, sample.pattern, sample.options, sample.match)
+		}
+	}
+}
+
+func TestWildcardErrors(t *testing.T) {
+	for _, pattern := range []string{
+		"[abc",
+		"[",
+		"[!",
+		"[z-a]",
+		"x\\",
+		"[a\\",
+		"{a,b",
+		"{a,{b,c}",
+	} {
+		if _, err := CompileWildcard(pattern, WildcardOptions{}); err == nil {
+			t.Errorf("expected error compiling %q", pattern)
+		}
+	}
+}
+
+func TestWildcardAllocs(t *testing.T) {
+	var w = MustCompileWildcard("{*.go,src/**/[a-z]*.txt}", WildcardOptions{Path: true})
+	if n := testing.AllocsPerRun(100, func() { w.Match("src/a/b/Readme.TXT") }); n != 0 {
+		t.Errorf("got %v allocs, expected 0", n)
+	}
+}
+
+// TestWildcardManyStars checks that patterns with many stars that fail to
+// match long strings do not take exponential time.
+func TestWildcardManyStars(t *testing.T) {
+	var (
+		text    = strings.Repeat("a", 200)
+		pattern = strings.Repeat("*a", 20) + "*b"
+		start   = time.Now()
+	)
+	for _, opts := range []WildcardOptions{{}, {Path: true}} {
+		var w = MustCompileWildcard(pattern, opts)
+		if w.Match(text) {
+			t.Errorf("%q matched %q", pattern, text)
+		}
+		if _, ok := w.Captures(text); ok {
+			t.Errorf("%q captured %q", pattern, text)
+		}
+		if _, ok := w.Replace(text, "$1"); ok {
+			t.Errorf("%q replaced %q", pattern, text)
+		}
+		if !w.Match(text + "b") {
+			t.Errorf("%q did not match %q", pattern, text+"b")
+		}
+	}
+	var w = MustCompileWildcard("**/"+strings.Repeat("*a/", 10)+"*b", WildcardOptions{Path: true})
+	if text = strings.Repeat("aa/", 60); w.Match(text) {
+		t.Errorf("%q matched %q", w, text)
+	}
+	if _, ok := w.Captures(text); ok {
+		t.Errorf("%q captured %q", w, text)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("matching many stars took %v", d)
+	}
+}
+
+func BenchmarkWildcardMatch(b *testing.B) {
+	var w = MustCompileWildcard("Si?fero*ka?epe?l", WildcardOptions{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		w.Match("Sinferopopokatepetl")
+	}
+}
+
+func TestWildcardCaptures(t *testing.T) {
+	var w = MustCompileWildcard("src/**/*.[ch]", WildcardOptions{Path: true})
+
+	caps, ok := w.Captures("src/lib/util/strings.c")
+	if !ok {
+		t.Fatal("Captures failed to match")
+	}
+	var expected = []WildcardCapture{
+		{Start: 4, End: 13, Text: "lib/util/"},
+		{Start: 13, End: 20, Text: "strings"},
+		{Start: 21, End: 22, Text: "c"},
+	}
+	if len(caps) != len(expected) {
+		t.Fatalf("got %d captures, expected %d", len(caps), len(expected))
+	}
+	for i := range caps {
+		if caps[i] != expected[i] {
+			t.Errorf("got %+v, expected %+v", caps[i], expected[i])
+		}
+	}
+
+	if _, ok = w.Captures("src/main.go"); ok {
+		t.Error("Captures matched unexpectedly")
+	}
+
+	w = MustCompileWildcard("{*.tar.gz,*.t?z}", WildcardOptions{})
+	if caps, ok = w.Captures("ARCHIVE.TGZ"); !ok || len(caps) != 2 || caps[0].Text != "ARCHIVE" || caps[1].Text != "G" {
+		t.Errorf("unexpected captures %+v", caps)
+	}
+}
+
+func TestWildcardReplace(t *testing.T) {
+	type replaceSample struct {
+		pattern  string
+		str      string
+		template string
+		out      string
+	}
+	samples := []replaceSample{
+		{"*.txt", "notes.txt", "out/$1.bak", "out/notes.bak"},
+		{"*-*", "left-right", "$2-$1", "right-left"},
+		{"*-*", "left-right", "${2}x${1}", "rightxleft"},
+		{"*.txt", "notes.txt", "$0 $$1 $ $x ${1 $9", "notes.txt $1 $ $x ${1 "},
+		{"?", "a", "$", "$"},
+	}
+
+	for _, sample := range samples {
+		var w = MustCompileWildcard(sample.pattern, WildcardOptions{})
+		out, ok := w.Replace(sample.str, sample.template)
+		if !ok {
+			t.Errorf("Replace failed to match %q against %q", sample.str, sample.pattern)
+		}
+		if out != sample.out {
+			t.Errorf("got %q from %q, expected %q", out, sample.template, sample.out)
+		}
+	}
+
+	if _, ok := MustCompileWildcard("*.txt", WildcardOptions{}).Replace("notes.md", "$1"); ok {
+		t.Error("Replace matched unexpectedly")
+	}
+}
+
+func TestValidateWildcard(t *testing.T) {
+	type validateSample struct {
+		pattern string
+		pos     int
+	}
+	samples := []validateSample{
+		{"[abc", 0},
+		{"ab[", 2},
+		{"a[!", 1},
+		{"a[bz-a]", 3},
+		{"x\\", 1},
+		{"[a\\", 2},
+		{"x{a,b", 1},
+		{"{a,{b,c}", 0},
+	}
+
+	for _, sample := range samples {
+		var (
+			err  = ValidateWildcard(sample.pattern)
+			werr *WildcardError
+		)
+		if !errors.As(err, &werr) {
+			t.Errorf("expected WildcardError for %q, got %v", sample.pattern, err)
+			continue
+		}
+		if werr.Pos != sample.pos || werr.Pattern != sample.pattern {
+			t.Errorf("got position %d for %q, expected %d", werr.Pos, sample.pattern, sample.pos)
+		}
+	}
+
+	for _, sample := range wildcardSamples {
+		if err := ValidateWildcard(sample.pattern); err != nil {
+			t.Errorf("unexpected error for %q: %v", sample.pattern, err)
+		}
+	}
+}
+
+func TestWildcardRegexp(t *testing.T) {
+	for _, sample := range wildcardSamples {
+		expr, err := WildcardRegexp(sample.pattern, sample.options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			t.Errorf("invalid regexp %q from %q: %v", expr, sample.pattern, err)
+			continue
+		}
+		if match := re.MatchString(sample.str); match != sample.match {
+			t.Errorf("got %t matching %q against %q (%q), expected %t",
+				match, sample.str, expr, sample.pattern, sample.match)
+		}
+	}
+
+	if _, err := WildcardRegexp("[abc", WildcardOptions{}); err == nil {
+		t.Error("expected error for malformed pattern")
+	}
+}
+
+// TestWildcardRegexpAgrees checks that random patterns and their regular
+// expression translations match the same random strings.
+func TestWildcardRegexpAgrees(t *testing.T) {
+	const (
+		patternChars = "ab/*?[]!-{},\\Ä"
+		textChars    = "abAB/-ä"
+	)
+	var (
+		rnd     = rand.New(rand.NewSource(1))
+		options = []WildcardOptions{{}, {CaseSensitive: true}, {Path: true}, {CaseSensitive: true, Path: true}}
+		random  = func(set string, max int) string {
+			var r = []rune(set)
+			var b = make([]rune, rnd.Intn(max+1))
+			for i := range b {
+				b[i] = r[rnd.Intn(len(r))]
+			}
+			return string(b)
+		}
+	)
+
+	for i := 0; i < 3000; i++ {
+		var pattern = random(patternChars, 8)
+		for _, opts := range options {
+			w, err := CompileWildcard(pattern, opts)
+			if err != nil {
+				if ValidateWildcard(pattern) == nil {
+					t.Fatalf("ValidateWildcard accepted %q: %v", pattern, err)
+				}
+				break
+			}
+			re, err := regexp.Compile(w.Regexp())
+			if err != nil {
+				t.Fatalf("invalid regexp %q from %q: %v", w.Regexp(), pattern, err)
+			}
+			for j := 0; j < 20; j++ {
+				var text = random(textChars, 8)
+				if a, b := w.Match(text), re.MatchString(text); a != b {
+					t.Fatalf("wildcard %q %+v matched %q: %t, regexp %q: %t", pattern, opts, text, a, w.Regexp(), b)
+				}
+				if _, c := w.Captures(text); c != re.MatchString(text) {
+					t.Fatalf("wildcard %q %+v captured %q: %t, regexp %q: %t", pattern, opts, text, c, w.Regexp(), !c)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 Vedran Vuk. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package strutils
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// WildcardSet is a set of wildcard patterns matched together.
+//
+// Patterns are evaluated gitignore-style: a pattern prefixed with "!" is
+// negated and the last pattern in the set that matches a string decides
+// the result. Patterns are indexed by their literal prefix and filtered by
+// their literal suffix so that only patterns that can possibly match a string
+// are evaluated.
+//
+// Use "\!" to match a literal "!" at the start of a pattern.
+//
+// A WildcardSet is safe for concurrent use once all patterns are added.
+type WildcardSet struct {
+	options  WildcardOptions
+	patterns []wildSetPattern
+	// root indexes patterns by literal prefix. Patterns without a literal
+	// prefix are stored at root.
+	root wildTrie
+}
+
+// wildSetPattern is a pattern in a WildcardSet.
+type wildSetPattern struct {
+	source   string
+	negate   bool
+	suffix   string
+	wildcard *Wildcard
+}
+
+// wildTrie is a rune trie node that indexes pattern indexes by literal
+// prefix.
+type wildTrie struct {
+	next     map[rune]*wildTrie
+	patterns []int
+}
+
+// NewWildcardSet returns a new WildcardSet of patterns compiled with options
+// or an error if any pattern is malformed.
+func NewWildcardSet(options WildcardOptions, patterns ...string) (*WildcardSet, error) {
+	var set = &WildcardSet{options: options}
+	for _, pattern := range patterns {
+		if err := set.Add(pattern); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+// Add compiles and adds pattern to the end of the set or returns an error
+// if pattern is malformed.
+func (self *WildcardSet) Add(pattern string) error {
+	var p = wildSetPattern{source: pattern}
+	if len(pattern) > 0 && pattern[0] == '!' {
+		p.negate = true
+		pattern = pattern[1:]
+	}
+	var err error
+	if p.wildcard, err = CompileWildcard(pattern, self.options); err != nil {
+		return err
+	}
+	p.suffix = p.wildcard.literalSuffix()
+	self.patterns = append(self.patterns, p)
+
+	var node = &self.root
+	for _, r := range p.wildcard.literalPrefix() {
+		if !self.options.CaseSensitive {
+			r = foldRune(r)
+		}
+		var next, exists = node.next[r]
+		if !exists {
+			if node.next == nil {
+				node.next = make(map[rune]*wildTrie)
+			}
+			next = new(wildTrie)
+			node.next[r] = next
+		}
+		node = next
+	}
+	node.patterns = append(node.patterns, len(self.patterns)-1)
+	return nil
+}
+
+// Len returns the number of patterns in the set.
+func (self *WildcardSet) Len() int { return len(self.patterns) }
+
+// Pattern returns the pattern at index i as it was added.
+func (self *WildcardSet) Pattern(i int) string { return self.patterns[i].source }
+
+// Match returns the index of the last pattern in the set that matches s and
+// true if the pattern is not negated or false if it is. If no pattern
+// matches s result is -1 and false.
+func (self *WildcardSet) Match(s string) (index int, ok bool) {
+	var (
+		buf   [32]int
+		cands = buf[:0]
+		node  = &self.root
+	)
+	for _, r := range s {
+		if !self.options.CaseSensitive {
+			r = foldRune(r)
+		}
+		if node = node.next[r]; node == nil {
+			break
+		}
+		cands = append(cands, node.patterns...)
+	}
+	slices.Sort(cands)
+
+	// Merge prefixed candidates with patterns without a prefix, both sorted,
+	// and evaluate from last to first.
+	var (
+		always = self.root.patterns
+		i, j   = len(cands) - 1, len(always) - 1
+	)
+	for i >= 0 || j >= 0 {
+		if j < 0 || i >= 0 && cands[i] > always[j] {
+			index, i = cands[i], i-1
+		} else {
+			index, j = always[j], j-1
+		}
+		if p := &self.patterns[index]; self.hasSuffix(s, p.suffix) && p.wildcard.Match(s) {
+			return index, !p.negate
+		}
+	}
+	return -1, false
+}
+
+// Matches returns true if the last pattern in the set that matches s is not
+// negated.
+func (self *WildcardSet) Matches(s string) bool {
+	_, ok := self.Match(s)
+	return ok
+}
+
+// hasSuffix returns true if s ends with suffix, respecting set options.
+func (self *WildcardSet) hasSuffix(s, suffix string) bool {
+	if self.options.CaseSensitive {
+		return strings.HasSuffix(s, suffix)
+	}
+	_, ok := suffixFold(s, suffix)
+	return ok
+}
+
+// literalPrefix returns the literal prefix common to all alternatives of the
+// pattern.
+func (self *Wildcard) literalPrefix() string {
+	var prefix string
+	for i, alt := range self.alts {
+		if len(alt) == 0 || alt[0].kind != wildLiteral {
+			return ""
+		}
+		if i == 0 {
+			prefix = alt[0].lit
+			continue
+		}
+		var n = 0
+		for n < len(prefix) && n < len(alt[0].lit) && prefix[n] == alt[0].lit[n] {
+			n++
+		}
+		for n > 0 && n < len(prefix) && !utf8.RuneStart(prefix[n]) {
+			n--
+		}
+		prefix = prefix[:n]
+	}
+	return prefix
+}
+
+// literalSuffix returns the literal suffix common to all alternatives of the
+// pattern.
+func (self *Wildcard) literalSuffix() string {
+	var suffix string
+	for i, alt := range self.alts {
+		if len(alt) == 0 || alt[len(alt)-1].kind != wildLiteral {
+			return ""
+		}
+		var lit = alt[len(alt)-1].lit
+		if i == 0 {
+			suffix = lit
+			continue
+		}
+		var n = 0
+		for n < len(suffix) && n < len(lit) && suffix[len(suffix)-1-n] == lit[len(lit)-1-n] {
+			n++
+		}
+		for n > 0 && !utf8.RuneStart(suffix[len(suffix)-n]) {
+			n--
+		}
+		suffix = suffix[len(suffix)-n:]
+	}
+	return suffix
+}
@@ -0,0 +1,183 @@
+package strutils
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWildcardSet(t *testing.T) {
+	set, err := NewWildcardSet(WildcardOptions{Path: true},
+		"*.log",
+		"build/**",
+		"!build/keep/**",
+		"!important.log",
+		"\\!bang",
+		"Docs/{*.md,*.txt}",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type setSample struct {
+		str   string
+		index int
+		ok    bool
+	}
+	samples := []setSample{
+		{"debug.log", 0, true},
+		{"IMPORTANT.LOG", 3, false},
+		{"build/out/main.o", 1, true},
+		{"build/keep/readme", 2, false},
+		{"src/main.go", -1, false},
+		{"dir/debug.log", -1, false},
+		{"!bang", 4, true},
+		{"bang", -1, false},
+		{"docs/readme.MD", 5, true},
+		{"docs/readme.go", -1, false},
+	}
+
+	for _, sample := range samples {
+		index, ok := set.Match(sample.str)
+		if index != sample.index || ok != sample.ok {
+			t.Errorf("got %d, %t from %q, expected %d, %t", index, ok, sample.str, sample.index, sample.ok)
+		}
+		if set.Matches(sample.str) != sample.ok {
+			t.Errorf("Matches failed for %q", sample.str)
+		}
+	}
+
+	if set.Len() != 6 || set.Pattern(2) != "!build/keep/**" {
+		t.Error("Len or Pattern failed")
+	}
+
+	if _, err = NewWildcardSet(WildcardOptions{}, "ok", "[bad"); err == nil {
+		t.Error("expected error for malformed pattern")
+	}
+}
+
+func TestWildcardSetAgreesWithWildcard(t *testing.T) {
+	var (
+		patterns []string
+		options  = WildcardOptions{}
+	)
+	for _, sample := range wildcardSamples {
+		if sample.options == options && sample.pattern != "" && sample.pattern[0] != '!' {
+			patterns = append(patterns, sample.pattern)
+		}
+	}
+	set, err := NewWildcardSet(options, patterns...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sample := range wildcardSamples {
+		var expected = -1
+		for i, pattern := range patterns {
+			if MustCompileWildcard(pattern, options).Match(sample.str) {
+				expected = i
+			}
+		}
+		if index, _ := set.Match(sample.str); index != expected {
+			t.Errorf("got %d from %q, expected %d", index, sample.str, expected)
+		}
+	}
+}
+
+// TestWildcardSetManyStars checks that a rule with many stars does not stall
+// lookups of other strings.
+func TestWildcardSetManyStars(t *testing.T) {
+	var patterns, names = wildcardSetManyStars()
+	set, err := NewWildcardSet(WildcardOptions{Path: true}, patterns...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var start = time.Now()
+	for _, name := range names {
+		if index, ok := set.Match(name); index != 0 || !ok {
+			t.Errorf("got %d, %t from %q, expected 0, true", index, ok, name)
+		}
+	}
+	if index, ok := set.Match(names[0] + "b"); index != 1 || !ok {
+		t.Errorf("got %d, %t, expected 1, true", index, ok)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("matching many stars took %v", d)
+	}
+}
+
+// wildcardSetManyStars returns a catch-all pattern followed by a pattern
+// with many stars and no literal suffix, and names that nearly match the
+// latter.
+func wildcardSetManyStars() (patterns, names []string) {
+	patterns = []string{"*", strings.Repeat("*a", 12) + "*b*"}
+	for n := 40; n <= 200; n += 40 {
+		names = append(names, strings.Repeat("a", n))
+	}
+	return
+}
+
+// wildcardSetBenchmark returns benchmark patterns and names.
+func wildcardSetBenchmark() (patterns, names []string) {
+	for i := 0; i < 100; i++ {
+		var n = strconv.Itoa(i)
+		patterns = append(patterns,
+			"module"+n+"/*.go",
+			"*.tmp"+n,
+			"!module"+n+"/keep?.go",
+		)
+	}
+	for i := 0; i < 100; i += 7 {
+		var n = strconv.Itoa(i)
+		names = append(names, "module"+n+"/main.go", "module"+n+"/keep1.go", "cache.tmp"+n, "readme.md")
+	}
+	return
+}
+
+func BenchmarkWildcardSet(b *testing.B) {
+	var patterns, names = wildcardSetBenchmark()
+	set, err := NewWildcardSet(WildcardOptions{}, patterns...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, name := range names {
+			set.Match(name)
+		}
+	}
+}
+
+func BenchmarkWildcardSetManyStars(b *testing.B) {
+	var patterns, names = wildcardSetManyStars()
+	set, err := NewWildcardSet(WildcardOptions{Path: true}, patterns...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, name := range names {
+			set.Match(name)
+		}
+	}
+}
+
+func BenchmarkWildcardSetNaive(b *testing.B) {
+	var patterns, names = wildcardSetBenchmark()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, name := range names {
+			var included = false
+			for _, pattern := range patterns {
+				if pattern[0] == '!' {
+					if MatchesWildcard(name, pattern[1:]) {
+						included = false
+					}
+				} else if MatchesWildcard(name, pattern) {
+					included = true
+				}
+			}
+			_ = included
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 Vedran Vuk. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package strutils
+
+import (
+	"iter"
+	"strings"
+)
+
+// WordRules defines how a string is segmented into words.
+//
+// Words are scanned from ASCII bytes. The zero value treats any run of ASCII
+// letters and digits as a single word and any other byte as a separator.
+// Boundary fields add further rules that split such runs into multiple
+// words.
+type WordRules struct {
+	// CaseBoundary, if true, starts a new word at an uppercase letter that
+	// follows a lowercase letter anywhere in the current word, so that a word
+	// is a run of uppercase letters followed by a run of lowercase letters.
+	// Digits do not change case state and attach to either run.
+	//
+	// "fooBar" yields "foo", "Bar" and "lk0B" yields "lk0", "B".
+	CaseBoundary bool
+
+	// DigitBoundary, if true, starts a new word at every transition between a
+	// letter and a digit.
+	//
+	// "base64Encode" yields "base", "64", "Encode".
+	DigitBoundary bool
+
+	// AcronymBoundary, if true, ends a run of uppercase letters before the
+	// last uppercase letter if it is followed by a lowercase letter.
+	//
+	// "HTTPServer" yields "HTTP", "Server".
+	AcronymBoundary bool
+
+	// Separators, if not empty, is the set of bytes that separate words. Bytes
+	// that are neither separators nor ASCII letters or digits become a part
+	// of a word and never start a new one.
+	//
+	// If empty, any byte that is not an ASCII letter or digit is a separator.
+	Separators string
+}
+
+// DefaultWordRules are the rules used by [Words] and [WordsSeq].
+var DefaultWordRules = WordRules{CaseBoundary: true}
+
+var (
+	// separatorWords are word rules used by snake_case and kebab-case.
+	separatorWords = WordRules{CaseBoundary: true}
+	// camelWords are word rules used by camelCase and PascalCase.
+	camelWords = WordRules{CaseBoundary: true, DigitBoundary: true}
+)
+
+// Words returns words of s as defined by [DefaultWordRules].
+func Words(s string) []string { return DefaultWordRules.Words(s) }
+
+// WordsSeq returns an iterator over words of s as defined by
+// [DefaultWordRules].
+func WordsSeq(s string) iter.Seq[string] { return DefaultWordRules.WordsSeq(s) }
+
+// Words returns words of s as defined by self.
+// Returned words are substrings of s.
+func (self WordRules) Words(s string) (out []string) {
+	for start, end := self.next(s, 0); start < end; start, end = self.next(s, end) {
+		out = append(out, s[start:end])
+	}
+	return
+}
+
+// WordsSeq returns an iterator over words of s as defined by self.
+// Yielded words are substrings of s.
+func (self WordRules) WordsSeq(s string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for start, end := self.next(s, 0); start < end; start, end = self.next(s, end) {
+			if !yield(s[start:end]) {
+				return
+			}
+		}
+	}
+}
+
+// next returns the start and end index of the next word in s, scanning from
+// i. If there are no more words in s start and end are equal.
+func (self WordRules) next(s string, i int) (start, end int) {
+
+	// skip leading separators
+	for i < len(s) && self.isSeparator(s[i]) {
+		i++
+	}
+	if start = i; i == len(s) {
+		return i, i
+	}
+
+	var lower = IsLower(s[i])
+	for i++; i < len(s); i++ {
+		var c, p = s[i], s[i-1]
+		if self.isSeparator(c) {
+			break
+		}
+		if self.DigitBoundary && (IsDigit(c) && IsLetter(p) || IsLetter(c) && IsDigit(p)) {
+			break
+		}
+		if IsUpper(c) {
+			if self.CaseBoundary && lower {
+				break
+			}
+			if self.AcronymBoundary && !lower && i+1 < len(s) && IsLower(s[i+1]) {
+				break
+			}
+		}
+		if IsLower(c) {
+			lower = true
+		}
+	}
+	return start, i
+}
+
+// isSeparator returns true if c separates words.
+func (self WordRules) isSeparator(c byte) bool {
+	if self.Separators == "" {
+		return !IsAlphanumeric(c)
+	}
+	return strings.IndexByte(self.Separators, c) >= 0
+}
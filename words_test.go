@@ -0,0 +1,64 @@
+package strutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	type wordsSample struct {
+		str   string
+		rules WordRules
+		out   []string
+	}
+	samples := []wordsSample{
+		{"", DefaultWordRules, nil},
+		{"  $#$ ", DefaultWordRules, nil},
+		{"sample text", DefaultWordRules, []string{"sample", "text"}},
+		{"inviteYourCustomers", DefaultWordRules, []string{"invite", "Your", "Customers"}},
+		{"lk0B@bFmjrLQ_Z6YL", DefaultWordRules, []string{"lk0", "B", "b", "Fmjr", "LQ", "Z6YL"}},
+		{"CStringRef", DefaultWordRules, []string{"CString", "Ref"}},
+		{"fooBar", WordRules{}, []string{"fooBar"}},
+		{"base64Encode", WordRules{CaseBoundary: true, DigitBoundary: true}, []string{"base", "64", "Encode"}},
+		{"2FA Enabled", WordRules{DigitBoundary: true}, []string{"2", "FA", "Enabled"}},
+		{"HTTPServer", WordRules{AcronymBoundary: true}, []string{"HTTP", "Server"}},
+		{"CStringRef", WordRules{CaseBoundary: true, AcronymBoundary: true}, []string{"C", "String", "Ref"}},
+		{"HTTP2Server", WordRules{CaseBoundary: true, AcronymBoundary: true}, []string{"HTTP2", "Server"}},
+		{"don't stop-me now", WordRules{Separators: " -"}, []string{"don't", "stop", "me", "now"}},
+		{"a.b c", WordRules{Separators: " "}, []string{"a.b", "c"}},
+	}
+
+	for _, sample := range samples {
+		if out := sample.rules.Words(sample.str); !reflect.DeepEqual(out, sample.out) {
+			t.Errorf("got %q from %q, expected %q", out, sample.str, sample.out)
+		}
+		var seq []string
+		for word := range sample.rules.WordsSeq(sample.str) {
+			seq = append(seq, word)
+		}
+		if !reflect.DeepEqual(seq, sample.out) {
+			t.Errorf("got %q from %q iterator, expected %q", seq, sample.str, sample.out)
+		}
+	}
+}
+
+func TestWordsSeqBreak(t *testing.T) {
+	var n int
+	for range WordsSeq("one two three") {
+		if n++; n == 2 {
+			break
+		}
+	}
+	if n != 2 {
+		t.Errorf("got %d words, expected 2", n)
+	}
+}
+
+func BenchmarkWords(b *testing.B) {
+	var s = "some sample text here_noething:too$amazing"
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		for range WordsSeq(s) {
+		}
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2026 Vedran Vuk. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package strutils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WrapText wraps text into multiple lines at first whitespace before or exactly
+// at cols.
+//
+// Columns are measured in display width, see [DisplayWidth], so that lines
+// of East Asian wide characters, emoji and text with combining marks line up
+// in a terminal. Lines are broken at spaces, tabs and newlines; a CR LF pair
+// is a newline. White space at the start and end of wrapped lines is
+// removed, white space between words is kept. A blank line in text is an
+// empty line in out.
+//
+// Lines are also broken where Unicode line breaking rules allow a break
+// without white space: after a hyphen between letters, after a slash in a
+// URL or path, after a zero width space and before and after East Asian
+// ideographs, except before closing and after opening punctuation.
+//
+// If a word is longer than cols and force is true it is split at cols length
+// regardless of white space, between grapheme clusters. If force is false,
+// the word is not split and placed into its own line at first next
+// whitespace.
+//
+// ANSI escape sequences occupy no columns and are never split. If text is
+// styled with SGR sequences, such as colours, the style active at the start
+// of a line is re-applied at its start and reset at its end so that every
+// line can be printed on its own.
+//
+// Lines are slices of text unless they need styles re-applied or a hyphen
+// inserted. If cols is less than 1 it is 1.
+//
+// See [WrapOptions] for more options.
+func WrapText(text string, cols int, force bool) []string {
+	return WrapOptions{Force: force}.Wrap(text, cols)
+}
+
+// WrapOptions define how [WrapOptions.Wrap] wraps text.
+type WrapOptions struct {
+	// Force, if true, splits words longer than the line width.
+	Force bool
+
+	// Hyphenate, if true, inserts a hyphen where Force splits a word between
+	// two letters.
+	Hyphenate bool
+
+	// TabWidth is the distance between tab stops in columns.
+	//
+	// Default: 8
+	TabWidth int
+}
+
+// Wrap wraps text into lines at most cols wide as defined by self.
+// See [WrapText] for details.
+func (self WrapOptions) Wrap(text string, cols int) []string {
+	var w = &textWrapper{cols: max(cols, 1), WrapOptions: self}
+	for len(text) > 0 {
+		var line, rest, _ = strings.Cut(text, "\n")
+		w.wrap(strings.TrimSuffix(line, "\r"))
+		text = rest
+	}
+	return w.out
+}
+
+// textWrapper wraps lines of text.
+type textWrapper struct {
+	WrapOptions
+	cols int
+	// first, if not zero, is the width of the first line of output.
+	first int
+	out   []string
+	// style is the style active at the start of the next line.
+	style sgrStyle
+}
+
+// wrap wraps a line of text s that contains no newlines.
+func (self *textWrapper) wrap(s string) {
+	var (
+		start, end = -1, 0         // current line bounds in s
+		width      = 0             // current line width
+		lines      = len(self.out) // lines before s
+	)
+	for i := 0; i < len(s); {
+		if isWrapSpace(s[i]) {
+			i++
+			continue
+		}
+		var (
+			j = pieceEnd(s, i)
+			w = DisplayWidth(s[i:j])
+		)
+		if start >= 0 {
+			var gap = self.gapWidth(s[end:i], width)
+			if width+gap+w <= self.limit() {
+				width, end, i = width+gap+w, j, j
+				continue
+			}
+			self.emit(s[start:end])
+		}
+		start, end, width, i = i, j, w, j
+		for self.Force && width > self.limit() {
+			var n, nw = self.split(s[start:end])
+			start, width = start+n, width-nw
+		}
+		if start == end {
+			start = -1
+		}
+	}
+	switch {
+	case start >= 0:
+		self.emit(s[start:end])
+	case len(self.out) == lines:
+		self.out = append(self.out, "")
+	}
+}
+
+// split emits the longest prefix of word that fits the next line, with a
+// hyphen if Hyphenate is set and the word is split between letters, and
+// returns its length and width.
+func (self *textWrapper) split(word string) (n, width int) {
+	var limit = self.limit()
+	if self.Hyphenate && limit > 1 {
+		if n, width = splitWidth(word, limit-1); n < len(word) && width > 0 {
+			var (
+				last, _ = utf8.DecodeLastRuneInString(StripANSI(word[:n]))
+				next, _ = utf8.DecodeRuneInString(StripANSI(word[n:]))
+			)
+			if unicode.IsLetter(last) && unicode.IsLetter(next) && RuneWidth(last) == 1 {
+				self.emit(word[:n] + "-")
+				return
+			}
+		}
+	}
+	n, width = splitWidth(word, limit)
+	self.emit(word[:n])
+	return
+}
+
+// gapWidth returns the width of white space s at column col.
+func (self *textWrapper) gapWidth(s string, col int) int {
+	var tab = self.TabWidth
+	if tab <= 0 {
+		tab = 8
+	}
+	var start = col
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\t' {
+			col += tab - col%tab
+		} else {
+			col++
+		}
+	}
+	return col - start
+}
+
+// limit returns the width of the next line of output.
+func (self *textWrapper) limit() int {
+	if self.first > 0 && len(self.out) == 0 {
+		return self.first
+	}
+	return self.cols
+}
+
+// emit appends line to output, re-applying and resetting the active style.
+func (self *textWrapper) emit(line string) {
+	if strings.IndexByte(line, ansiEscape) < 0 && self.style == "" {
+		self.out = append(self.out, line)
+		return
+	}
+	var (
+		style = self.style
+		next  = style.scan(line)
+	)
+	self.style = next
+	if style == "" && next == "" {
+		self.out = append(self.out, line)
+		return
+	}
+	var buf = make([]byte, 0, len(style)+len(line)+len(ansiReset))
+	buf = append(buf, style...)
+	buf = append(buf, line...)
+	if next != "" {
+		buf = append(buf, ansiReset...)
+	}
+	self.out = append(self.out, UnsafeString(buf))
+}
+
+// splitWidth returns the length and width of the longest prefix of s made of
+// whole grapheme clusters and escape sequences that is at most cols wide, or
+// of the first cluster if it alone is wider than cols.
+func splitWidth(s string, cols int) (n, width int) {
+	for n < len(s) {
+		var size, w = nextCluster(s[n:])
+		if width+w > cols && n > 0 {
+			break
+		}
+		n, width = n+size, width+w
+	}
+	return
+}
+
+// isWrapSpace returns true if c is white space at which lines are broken and
+// which is removed at line ends.
+func isWrapSpace(c byte) bool { return c == ' ' || c == '\t' }
+
+// pieceEnd returns the end of the piece of s that starts at i and extends to
+// the next white space or line break opportunity.
+func pieceEnd(s string, i int) int {
+	var before, prev rune = -1, -1 // runes of the two clusters before j
+	var prevWidth = 0
+	for j := i; j < len(s); {
+		if isWrapSpace(s[j]) {
+			return j
+		}
+		if n := ansiLen(s[j:]); n > 0 {
+			j += n
+			continue
+		}
+		var (
+			n, w = nextGrapheme(s[j:])
+			r, _ = utf8.DecodeRuneInString(s[j:])
+		)
+		if prev >= 0 && breakBetween(before, prev, prevWidth, r, w) {
+			return j
+		}
+		before, prev, prevWidth = prev, r, w
+		j += n
+	}
+	return len(s)
+}
+
+// breakBetween returns true if a line may be broken between a cluster that
+// starts with rune a of width aw, preceded by a cluster that starts with
+// before or -1, and a cluster that starts with rune b of width bw.
+func breakBetween(before, a rune, aw int, b rune, bw int) bool {
+	switch {
+	case strings.ContainsRune(noBreakBefore, b) || strings.ContainsRune(noBreakAfter, a):
+		return false
+	case a == 0x200b || a == 0x3000 || a >= 0x2000 && a <= 0x200a && a != 0x2007:
+		// Zero width and breaking spaces.
+		return true
+	case a == '-' || a == 0x2010 || a == 0x2013:
+		// Hyphens and en dash between letters.
+		return unicode.IsLetter(before) && unicode.IsLetter(b)
+	case a == '/':
+		// Slashes in URLs and paths, but not between slashes or before
+		// digits.
+		return before >= 0 && before != '/' && b != '/' && !unicode.IsDigit(b)
+	}
+	// Ideographs.
+	return aw == 2 && isIdeographic(a) || bw == 2 && isIdeographic(b)
+}
+
+// isIdeographic returns true if r is an East Asian wide letter or symbol
+// around which lines may be broken.
+func isIdeographic(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		r >= 0x3000 && r <= 0x303f || isPictographic(r)
+}
+
+const (
+	// noBreakBefore are closing punctuation and characters that may not
+	// start a line.
+	noBreakBefore = ")]}>,.;:!?%\"'" +
+		"、。，．：；！？）」』】〕〉》〗〙〛・ー々〻" +
+		"ぁぃぅぇぉっゃゅょゎゕゖァィゥェォッャュョヮヵヶ" +
+		"）］｝，．：；！？"
+	// noBreakAfter are opening punctuation and characters that may not end a
+	// line.
+	noBreakAfter = "([{<\"'" +
+		"（「『【〔〈《〖〘〚" +
+		"（［｛"
+)
@@ -0,0 +1,191 @@
+package strutils
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+const loremIpsum = `Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.
+
+Sed ut perspiciatis unde omnis iste natus error sit voluptatem accusantium doloremque laudantium, totam rem aperiam, eaque ipsa quae ab illo inventore veritatis et quasi architecto beatae vitae dicta sunt explicabo. Nemo enim ipsam voluptatem quia voluptas sit aspernatur aut odit aut fugit, sed quia consequuntur magni dolores eos qui ratione voluptatem sequi nesciunt. Neque porro quisquam est, qui dolorem ipsum quia dolor sit amet, consectetur, adipisci velit, sed quia non numquam eius modi tempora incidunt ut labore et dolore magnam aliquam quaerat voluptatem. Ut enim ad minima veniam, quis nostrum exercitationem ullam corporis suscipit laboriosam, nisi ut aliquid ex ea commodi consequatur? Quis autem vel eum iure reprehenderit qui in ea voluptate velit esse quam nihil molestiae consequatur, vel illum qui dolorem eum fugiat quo voluptas nulla pariatur?
+
+At vero eos et accusamus et iusto odio dignissimos ducimus qui blanditiis praesentium voluptatum deleniti atque corrupti quos dolores et quas molestias excepturi sint occaecati cupiditate non provident, similique sunt in culpa qui officia deserunt mollitia animi, id est laborum et dolorum fuga. Et harum quidem rerum facilis est et expedita distinctio. Nam libero tempore, cum soluta nobis est eligendi optio cumque nihil impedit quo minus id quod maxime placeat facere possimus, omnis voluptas assumenda est, omnis dolor repellendus. Temporibus autem quibusdam et aut officiis debitis aut rerum necessitatibus saepe eveniet ut et voluptates repudiandae sint et molestiae non recusandae. Itaque earum rerum hic tenetur a sapiente delectus, ut aut reiciendis voluptatibus maiores alias consequatur aut perferendis doloribus asperiores repellat.`
+
+func TestWrapText(t *testing.T) {
+	if testing.Verbose() {
+		for _, line := range WrapText(loremIpsum, 100, false) {
+			if testing.Verbose() {
+				fmt.Println(line)
+			}
+		}
+		return
+	}
+	for _, line := range WrapText(loremIpsum, 100, false) {
+		if w := DisplayWidth(line); w > 100 || line != strings.TrimSpace(line) {
+			t.Errorf("invalid line of width %d: %q", w, line)
+		}
+	}
+}
+
+func BenchmarkWrapText(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		WrapText(loremIpsum, 80, false)
+	}
+}
+
+func TestWrapTextWidth(t *testing.T) {
+	type wrapSample struct {
+		text  string
+		cols  int
+		force bool
+		out   []string
+	}
+	samples := []wrapSample{
+		{"", 10, false, nil},
+		{"\n", 10, false, []string{""}},
+		{"one two three", 7, false, []string{"one two", "three"}},
+		{"one two three", 6, false, []string{"one", "two", "three"}},
+		{"  one  two  ", 20, false, []string{"one  two"}},
+		{"one\n\ntwo\r\nthree", 20, false, []string{"one", "", "two", "three"}},
+		{"short extraordinarily long", 5, false, []string{"short", "extraordinarily", "long"}},
+		{"short extraordinarily long", 5, true, []string{"short", "extra", "ordin", "arily", "long"}},
+		{"ab abcdefg", 4, true, []string{"ab", "abcd", "efg"}},
+		{"čćžšđ ČĆŽŠĐ", 5, false, []string{"čćžšđ", "ČĆŽŠĐ"}},
+		{"привет мир", 6, false, []string{"привет", "мир"}},
+		{"日本語 テキスト", 6, false, []string{"日本語", "テキス", "ト"}},
+		{"日本語 テキスト", 6, true, []string{"日本語", "テキス", "ト"}},
+		{"日本語 テキスト", 7, false, []string{"日本語", "テキス", "ト"}},
+		{"日本語テキスト", 5, true, []string{"日本", "語テ", "キス", "ト"}},
+		{"e\u0301e\u0301e\u0301 x", 5, false, []string{"e\u0301e\u0301e\u0301 x"}},
+		{"e\u0301e\u0301e\u0301", 2, true, []string{"e\u0301e\u0301", "e\u0301"}},
+		{"👍🏽👍🏽 ok", 4, false, []string{"👍🏽👍🏽", "ok"}},
+		{"日", 1, true, []string{"日"}},
+		{"日 x", 1, true, []string{"日", "x"}},
+		{"abc", 0, true, []string{"a", "b", "c"}},
+	}
+
+	for _, sample := range samples {
+		if out := WrapText(sample.text, sample.cols, sample.force); !reflect.DeepEqual(out, sample.out) {
+			t.Errorf("got %q wrapping %q at %d, expected %q", out, sample.text, sample.cols, sample.out)
+		}
+	}
+}
+
+func TestWrapTextANSI(t *testing.T) {
+	const (
+		red   = "\x1b[31m"
+		bold  = "\x1b[1m"
+		reset = "\x1b[0m"
+	)
+	type wrapSample struct {
+		text  string
+		cols  int
+		force bool
+		out   []string
+	}
+	samples := []wrapSample{
+		{red + "one" + reset + " two", 7, false, []string{red + "one" + reset + " two"}},
+		{red + "one two" + reset + " three", 3, false, []string{
+			red + "one" + reset,
+			red + "two" + reset,
+			"three",
+		}},
+		{red + "one " + bold + "two three" + reset, 7, false, []string{
+			red + "one " + bold + "two" + reset,
+			red + bold + "three" + reset,
+		}},
+		{red + "abcdef" + reset, 2, true, []string{
+			red + "ab" + reset,
+			red + "cd" + reset,
+			red + "ef" + reset,
+		}},
+		{red + "one\n\ntwo" + reset, 10, false, []string{
+			red + "one" + reset,
+			"",
+			red + "two" + reset,
+		}},
+		{"\x1b[2 qab cd", 2, true, []string{"\x1b[2 qab", "cd"}},
+		{"\x1b]8;;http://x.y/a b\x07link\x1b]8;;\x07 next", 4, false, []string{
+			"\x1b]8;;http://x.y/a b\x07link\x1b]8;;\x07",
+			"next",
+		}},
+		{"\x1b[38;5;196m日本\x1b[m 語", 4, false, []string{
+			"\x1b[38;5;196m日本\x1b[m",
+			"語",
+		}},
+	}
+
+	for _, sample := range samples {
+		if out := WrapText(sample.text, sample.cols, sample.force); !reflect.DeepEqual(out, sample.out) {
+			t.Errorf("got %q wrapping %q at %d, expected %q", out, sample.text, sample.cols, sample.out)
+		}
+	}
+}
+
+func TestWrapTextBreaks(t *testing.T) {
+	type wrapSample struct {
+		opts WrapOptions
+		text string
+		cols int
+		out  []string
+	}
+	samples := []wrapSample{
+		{WrapOptions{}, "a\tb", 20, []string{"a\tb"}},
+		{WrapOptions{}, "abc\tdef", 10, []string{"abc", "def"}},
+		{WrapOptions{TabWidth: 4}, "abc\tdef", 10, []string{"abc\tdef"}},
+		{WrapOptions{TabWidth: 4}, "\tabc", 3, []string{"abc"}},
+		{WrapOptions{}, "a well-known fact", 8, []string{"a well-", "known", "fact"}},
+		{WrapOptions{}, "well-known", 10, []string{"well-known"}},
+		{WrapOptions{}, "set -5 or --flag", 4, []string{"set", "-5", "or", "--flag"}},
+		{WrapOptions{}, "x-5-y", 2, []string{"x-5-y"}},
+		{WrapOptions{}, "https://example.com/path/to", 20, []string{"https://example.com/", "path/to"}},
+		{WrapOptions{}, "see /usr/lib/go", 8, []string{"see", "/usr/", "lib/go"}},
+		{WrapOptions{}, "1/2/3", 2, []string{"1/2/3"}},
+		{WrapOptions{}, "日本語です。", 10, []string{"日本語で", "す。"}},
+		{WrapOptions{}, "日本語です。", 12, []string{"日本語です。"}},
+		{WrapOptions{}, "「日本」語", 6, []string{"「日", "本」語"}},
+		{WrapOptions{}, "abc\u200bdef", 4, []string{"abc\u200b", "def"}},
+		{WrapOptions{Force: true, Hyphenate: true}, "abcdefghij", 5, []string{"abcd-", "efgh-", "ij"}},
+		{WrapOptions{Force: true, Hyphenate: true}, "abc123", 4, []string{"abc1", "23"}},
+		{WrapOptions{Force: true, Hyphenate: true}, "日本語テキスト", 5, []string{"日本", "語テ", "キス", "ト"}},
+		{WrapOptions{Force: true, Hyphenate: true}, "abc", 1, []string{"a", "b", "c"}},
+		{WrapOptions{Hyphenate: true}, "abcdefghij", 5, []string{"abcdefghij"}},
+	}
+
+	for _, sample := range samples {
+		if out := sample.opts.Wrap(sample.text, sample.cols); !reflect.DeepEqual(out, sample.out) {
+			t.Errorf("got %q wrapping %q at %d %+v, expected %q", out, sample.text, sample.cols, sample.opts, sample.out)
+		}
+	}
+}
+
+// TestWrapTextUTF8 checks that wrapping multi-byte text neither garbles nor
+// loses it, as slicing text at rune indexes once did.
+func TestWrapTextUTF8(t *testing.T) {
+	var texts = []string{
+		"Čađava žaba ćuti šumom, ali Đuro ne čuje ništa. Éléphant naïve à côté du café.",
+		"Съешь же ещё этих мягких французских булок, да выпей чаю. Широкая электрификация.",
+		"日本語のテキストを折り返す。中文文本换行测试，한국어 텍스트 줄 바꿈 시험입니다.",
+		"Mešano: привет, 世界, café, 日本語テキスト and naïve résumé.",
+	}
+	for _, text := range texts {
+		var want = strings.Join(strings.Fields(text), "")
+		for cols := 1; cols <= 24; cols++ {
+			var out = WrapText(text, cols, true)
+			for _, line := range out {
+				if !utf8.ValidString(line) {
+					t.Errorf("invalid UTF-8 line %q wrapping %q at %d", line, text, cols)
+				}
+				if w := DisplayWidth(line); w > max(cols, 2) {
+					t.Errorf("line %q of width %d wrapping %q at %d", line, w, text, cols)
+				}
+			}
+			if got := strings.Join(strings.Fields(strings.Join(out, " ")), ""); got != want {
+				t.Errorf("got %q wrapping %q at %d, lost text", out, text, cols)
+			}
+		}
+	}
+}